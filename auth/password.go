@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrInvalidCredentials is returned when a password does not match its hash
+	ErrInvalidCredentials = errors.New("auth: invalid credentials")
+	// ErrPasswordTooLong is returned for passwords longer than the 72 bytes bcrypt can hash
+	ErrPasswordTooLong = bcrypt.ErrPasswordTooLong
+)
+
+// HashPassword returns the bcrypt hash of password
+func HashPassword(password string) (string, error) {
+	b, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// CheckPassword compares password with a hash made by HashPassword
+func CheckPassword(hash string, password string) error {
+	if hash == "" {
+		return ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignerIssuesVerifiableTokens(t *testing.T) {
+	signer := NewSigner([]byte("secret"), time.Hour)
+	token, expiresAt, err := signer.Issue(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := signer.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != 7 || claims.ExpiresAt != expiresAt.Unix() {
+		t.Errorf("got claims %+v, want user 7 expiring at %d", claims, expiresAt.Unix())
+	}
+}
+
+func TestSignerRejectsTamperedTokens(t *testing.T) {
+	signer := NewSigner([]byte("secret"), time.Hour)
+	token, _, err := signer.Issue(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, signature, _ := strings.Cut(token, ".")
+	forged, _, err := NewSigner([]byte("other secret"), time.Hour).Issue(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	tests := map[string]string{
+		"another user":       forgedPayload + "." + signature,
+		"another secret":     forged,
+		"changed signature":  payload + "." + strings.ToUpper(signature),
+		"without signature":  payload,
+		"empty signature":    payload + ".",
+		"not a token at all": "garbage",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := signer.Verify(token); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("got %v, want %v", err, ErrInvalidToken)
+			}
+		})
+	}
+}
+
+func TestSignerRejectsExpiredTokens(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	signer := NewSigner([]byte("secret"), time.Hour)
+	signer.now = func() time.Time { return now }
+	token, _, err := signer.Issue(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(time.Hour - time.Second)
+	if _, err = signer.Verify(token); err != nil {
+		t.Fatalf("token expired early: %v", err)
+	}
+	now = now.Add(time.Second)
+	if _, err = signer.Verify(token); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("got %v for an expired token, want %v", err, ErrInvalidToken)
+	}
+}
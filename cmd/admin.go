@@ -0,0 +1,95 @@
+/*
+Copyright © 2024 Serdil Cagin Cakmak serdilcakmak@gmail.com
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/lghtr35/quiz-maker/database"
+	"github.com/lghtr35/quiz-maker/migrations"
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/service"
+	"github.com/lghtr35/quiz-maker/store"
+	"github.com/spf13/cobra"
+)
+
+// adminPasswordEnv is the environment variable the admin command takes the password from
+const adminPasswordEnv = "QUIZ_MAKER_ADMIN_PASSWORD"
+
+// adminCmd represents the admin command
+var adminCmd = &cobra.Command{
+	Use:   "admin [Name]",
+	Short: "Make a user an admin, registering them when they do not exist",
+	Long: `Admin works on the database directly rather than through a server, so it is how operators bootstrap the first admin.
+An existing user is promoted and their password is replaced, otherwise a new admin is registered.
+The password is taken from QUIZ_MAKER_ADMIN_PASSWORD, or else read from the first line of stdin with a prompt on a terminal,
+so it does not end up in the shell history or the process list.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		password, err := readAdminPassword(cmd)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Open(cfg.Database.Driver, cfg.Database.DSN)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := database.Close(db); err != nil {
+				log.Printf("Closing database failed: %v", err)
+			}
+		}()
+
+		pending, err := migrations.Pending(db)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("database has %d pending migrations, run quiz-maker migrate up first", len(pending))
+		}
+
+		users := service.NewUserService(store.NewGormStore(db))
+		user, created, err := users.CreateAdmin(cmd.Context(), models.CreateUserRequest{Name: args[0], Password: password})
+		if err != nil {
+			return err
+		}
+		if created {
+			log.Printf("Registered admin %s with id %d", user.Name, user.ID)
+		} else {
+			log.Printf("Promoted %s with id %d to admin", user.Name, user.ID)
+		}
+		return nil
+	},
+}
+
+// readAdminPassword returns the password of the admin from QUIZ_MAKER_ADMIN_PASSWORD or the first line of stdin
+func readAdminPassword(cmd *cobra.Command) (string, error) {
+	if password, ok := os.LookupEnv(adminPasswordEnv); ok {
+		return password, nil
+	}
+	in := cmd.InOrStdin()
+	if f, ok := in.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			fmt.Fprint(cmd.ErrOrStderr(), "Password: ")
+		}
+	}
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("reading the password from stdin: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func init() {
+	rootCmd.AddCommand(adminCmd)
+
+	addDatabaseFlags(adminCmd.Flags())
+}
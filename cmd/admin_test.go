@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestReadAdminPassword(t *testing.T) {
+	t.Setenv(adminPasswordEnv, "")
+	os.Unsetenv(adminPasswordEnv)
+
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader("from stdin\nrest\n"))
+	if got, err := readAdminPassword(cmd); err != nil || got != "from stdin" {
+		t.Fatalf("got %q, %v, want the first line of stdin", got, err)
+	}
+	cmd.SetIn(strings.NewReader("no newline"))
+	if got, err := readAdminPassword(cmd); err != nil || got != "no newline" {
+		t.Fatalf("got %q, %v, want the whole of stdin", got, err)
+	}
+	cmd.SetIn(strings.NewReader(""))
+	if _, err := readAdminPassword(cmd); err == nil {
+		t.Fatal("an empty stdin gave a password")
+	}
+
+	t.Setenv(adminPasswordEnv, "from env")
+	cmd.SetIn(strings.NewReader("from stdin\n"))
+	if got, err := readAdminPassword(cmd); err != nil || got != "from env" {
+		t.Fatalf("got %q, %v, want the password from the environment", got, err)
+	}
+}
@@ -6,9 +6,12 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/lghtr35/quiz-maker/models"
 	"github.com/lghtr35/quiz-maker/util"
@@ -17,10 +20,12 @@ import (
 
 // answerCmd represents the answer command
 var answerCmd = &cobra.Command{
-	Use:   "answer [ProgressionId] [OptionId]",
+	Use:   "answer [ProgressionId] [OptionId...]",
 	Short: "Answer a question to progress in quiz",
-	Long:  `Answer a question to progress in quiz. It takes an optionId and progressionId to save an answer to the current question in quiz.`,
-	Args:  cobra.MinimumNArgs(2),
+	Long: `Answer a question to progress in quiz. It takes a progressionId and an optionId to save an answer to the current question in quiz.
+Multiple questions are answered with every picked optionId, text questions with --text, numeric questions with --number,
+ordering questions with every optionId in order through --order and matching questions with --pair optionId=item for every option.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Println("answer called")
 
@@ -28,44 +33,98 @@ var answerCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		optionId, err := strconv.ParseUint(args[1], 10, 32)
-		if err != nil {
-			return err
+		optionIds := make([]uint32, len(args)-1)
+		for i, arg := range args[1:] {
+			optionId, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				return err
+			}
+			optionIds[i] = uint32(optionId)
 		}
 
 		req := models.AnswerQuizQuestionRequest{
-			OptionID:      uint32(optionId),
 			ProgressionID: uint32(progressionId),
 		}
+		given := len(optionIds) > 0
+		for _, flag := range []string{"text", "number", "order", "pair"} {
+			if cmd.Flags().Changed(flag) {
+				if given {
+					return errors.New("answer with either option ids or one of --text, --number, --order and --pair")
+				}
+				given = true
+			}
+		}
+		if !given {
+			return errors.New("answer with either option ids or one of --text, --number, --order and --pair")
+		}
+		switch {
+		case cmd.Flags().Changed("text"):
+			text, _ := cmd.Flags().GetString("text")
+			req.Text = &text
+		case cmd.Flags().Changed("number"):
+			number, _ := cmd.Flags().GetFloat64("number")
+			req.Number = &number
+		case cmd.Flags().Changed("order"):
+			order, _ := cmd.Flags().GetUintSlice("order")
+			for _, id := range order {
+				req.Order = append(req.Order, uint32(id))
+			}
+		case cmd.Flags().Changed("pair"):
+			pairs, _ := cmd.Flags().GetStringArray("pair")
+			for _, p := range pairs {
+				id, item, ok := strings.Cut(p, "=")
+				if !ok {
+					return fmt.Errorf("pair %q is not in the form optionId=item", p)
+				}
+				optionId, err := strconv.ParseUint(id, 10, 32)
+				if err != nil {
+					return err
+				}
+				req.Pairs = append(req.Pairs, models.AnswerPair{OptionID: uint32(optionId), Pair: item})
+			}
+		case len(optionIds) == 1:
+			req.OptionID = optionIds[0]
+		default:
+			req.OptionIDs = optionIds
+		}
 
 		b, err := json.Marshal(req)
 		if err != nil {
 			return err
 		}
 		r := bytes.NewReader(b)
-		resp, err := http.Post("http://localhost:8080/quizzes/answer", "application/json", r)
+		resp, err := http.Post(endpoint("/quizzes/answer"), "application/json", r)
 		if err != nil {
 			return err
 		}
 		if resp.StatusCode != 200 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
 		}
 		unmarshalled, err := util.ReadBodyAndUnmarshal(models.AnswerQuizQuestionResponse{}, resp.Body)
 		if err != nil {
 			return err
 		}
+		if unmarshalled.TimedOut {
+			log.Println("Time is up, the answer was not saved")
+		}
 		log.Printf("Progression: %+v", unmarshalled.Progression)
+		if unmarshalled.CurrentQuestion != nil {
+			log.Printf("Question: %+v", *unmarshalled.CurrentQuestion)
+		}
+		if unmarshalled.Score != nil {
+			log.Printf("Score: %+v", *unmarshalled.Score)
+		}
+		printTimeRemaining(unmarshalled.TimeRemaining)
 		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(answerCmd)
+	answerCmd.Flags().String("text", "", "Answer of a text question")
+	answerCmd.Flags().Float64("number", 0, "Answer of a numeric question")
+	answerCmd.Flags().UintSlice("order", nil, "Every option id of an ordering question in order, e.g. --order 3,1,2")
+	answerCmd.Flags().StringArray("pair", nil, "An option id of a matching question and the item it is paired with, e.g. --pair 3=Paris")
 
 	// Here you will define your flags and configuration settings.
 
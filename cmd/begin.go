@@ -17,25 +17,20 @@ import (
 
 // beginCmd represents the begin command
 var beginCmd = &cobra.Command{
-	Use:   "begin [UserId] [QuizId]",
-	Short: "Begin a quiz as an user",
-	Long:  `Begin a quiz using QuizId as an user with UserId. It will return a Progression object for that user and quiz specifically`,
-	Args:  cobra.MinimumNArgs(2),
+	Use:   "begin [QuizId]",
+	Short: "Begin a quiz as the logged in user",
+	Long:  `Begin a quiz using QuizId as the logged in user. It will return a Progression object for that user and quiz specifically`,
+	Args:  cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Println("begin called")
 
-		userId, err := strconv.ParseUint(args[0], 10, 32)
-		if err != nil {
-			return err
-		}
-		quizId, err := strconv.ParseUint(args[1], 10, 32)
+		quizId, err := strconv.ParseUint(args[0], 10, 32)
 		if err != nil {
 			return err
 		}
 
 		req := models.BeginQuizRequest{
 			QuizID: uint32(quizId),
-			UserID: uint32(userId),
 		}
 
 		b, err := json.Marshal(req)
@@ -43,27 +38,36 @@ var beginCmd = &cobra.Command{
 			return err
 		}
 		r := bytes.NewReader(b)
-		resp, err := http.Post("http://localhost:8080/quizzes/begin", "application/json", r)
+		resp, err := http.Post(endpoint("/quizzes/begin"), "application/json", r)
 		if err != nil {
 			return err
 		}
 		if resp.StatusCode != 201 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
 		}
 		unmarshalled, err := util.ReadBodyAndUnmarshal(models.BeginQuizResponse{}, resp.Body)
 		if err != nil {
 			return err
 		}
 		log.Printf("Progression: %+v", unmarshalled.Progression)
+		if unmarshalled.CurrentQuestion != nil {
+			log.Printf("Question: %+v", *unmarshalled.CurrentQuestion)
+		}
+		printTimeRemaining(unmarshalled.TimeRemaining)
 		return nil
 	},
 }
 
+// printTimeRemaining logs how many seconds are left of a timed progression
+func printTimeRemaining(remaining models.TimeRemaining) {
+	if remaining.RemainingSeconds != nil {
+		log.Printf("Seconds left: %d", *remaining.RemainingSeconds)
+	}
+	if remaining.QuestionRemainingSeconds != nil {
+		log.Printf("Seconds left for this question: %d", *remaining.QuestionRemainingSeconds)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(beginCmd)
 
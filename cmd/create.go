@@ -6,11 +6,11 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lghtr35/quiz-maker/models"
 	"github.com/lghtr35/quiz-maker/util"
@@ -24,30 +24,26 @@ var createCmd = &cobra.Command{
 }
 
 var createUserCmd = &cobra.Command{
-	Use:   "user [name]",
-	Short: "Create User with name",
-	Args:  cobra.MinimumNArgs(1),
+	Use:   "user [Name] [Password]",
+	Short: "Create User with name and password, use them with login afterwards",
+	Args:  cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Println("create user called")
 		req := models.CreateUserRequest{
-			Name: args[0],
+			Name:     args[0],
+			Password: args[1],
 		}
 		b, err := json.Marshal(req)
 		if err != nil {
 			return err
 		}
 		r := bytes.NewReader(b)
-		resp, err := http.Post("http://localhost:8080/users", "application/json", r)
+		resp, err := http.Post(endpoint("/users"), "application/json", r)
 		if err != nil {
 			return err
 		}
 		if resp.StatusCode != 201 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
 		}
 		user, err := util.ReadBodyAndUnmarshal(models.User{}, resp.Body)
 		if err != nil {
@@ -90,22 +86,26 @@ var createQuizCmd = &cobra.Command{
 			Name:      name,
 			Questions: questionsRequests,
 		}
+		req.ShuffleQuestions, _ = cmd.Flags().GetBool("shuffle-questions")
+		req.ShuffleOptions, _ = cmd.Flags().GetBool("shuffle-options")
+		req.WrongPenalty, _ = cmd.Flags().GetFloat32("wrong-penalty")
+		req.UnansweredPenalty, _ = cmd.Flags().GetFloat32("unanswered-penalty")
+		req.PassThreshold, _ = cmd.Flags().GetFloat32("pass-threshold")
+		req.TimeLimit = seconds(cmd, "time-limit")
+		req.MaxAttempts, _ = cmd.Flags().GetUint32("max-attempts")
+		req.Cooldown = seconds(cmd, "cooldown")
+		req.ScorePolicy, _ = cmd.Flags().GetString("score-policy")
 		b, err := json.Marshal(req)
 		if err != nil {
 			return err
 		}
 		r := bytes.NewReader(b)
-		resp, err := http.Post("http://localhost:8080/quizzes", "application/json", r)
+		resp, err := http.Post(endpoint("/quizzes"), "application/json", r)
 		if err != nil {
 			return err
 		}
 		if resp.StatusCode != 201 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
 		}
 		quiz, err := util.ReadBodyAndUnmarshal(models.Quiz{}, resp.Body)
 		if err != nil {
@@ -130,26 +130,23 @@ var createOptionCmd = &cobra.Command{
 			return err
 		}
 
+		pair, _ := cmd.Flags().GetString("pair")
 		req := models.CreateOptionRequest{
 			Value:     value,
 			IsCorrect: isCorrect,
+			Pair:      pair,
 		}
 		b, err := json.Marshal(req)
 		if err != nil {
 			return err
 		}
 		r := bytes.NewReader(b)
-		resp, err := http.Post(fmt.Sprintf("http://localhost:8080/quizzes/questions/%s/options", qId), "application/json", r)
+		resp, err := http.Post(endpoint("/quizzes/questions/%s/options", qId), "application/json", r)
 		if err != nil {
 			return err
 		}
 		if resp.StatusCode != 201 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
 		}
 		option, err := util.ReadBodyAndUnmarshal(models.OptionBase{}, resp.Body)
 		if err != nil {
@@ -160,11 +157,99 @@ var createOptionCmd = &cobra.Command{
 	},
 }
 
+var createQuestionCmd = &cobra.Command{
+	Use:   "question [QuizId] [Question] [Options as json array]",
+	Short: "Add a question to a quiz. Options argument is not mandatory and left out for text and numeric questions.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("create question called")
+
+		if _, err := strconv.ParseUint(args[0], 10, 32); err != nil {
+			return err
+		}
+		req := models.CreateQuestionRequest{
+			Question: args[1],
+		}
+		req.Type, _ = cmd.Flags().GetString("type")
+		req.Scoring, _ = cmd.Flags().GetString("scoring")
+		if cmd.Flags().Changed("penalty") {
+			penalty, _ := cmd.Flags().GetFloat32("penalty")
+			req.Penalty = &penalty
+		}
+		if cmd.Flags().Changed("points") {
+			points, _ := cmd.Flags().GetFloat32("points")
+			req.Points = &points
+		}
+		req.TimeLimit = seconds(cmd, "time-limit")
+		req.AcceptedAnswers, _ = cmd.Flags().GetStringArray("accepted")
+		req.Matching, _ = cmd.Flags().GetString("matching")
+		req.CaseSensitive, _ = cmd.Flags().GetBool("case-sensitive")
+		if cmd.Flags().Changed("numeric") {
+			numeric, _ := cmd.Flags().GetFloat64("numeric")
+			req.NumericAnswer = &numeric
+		}
+		req.Tolerance, _ = cmd.Flags().GetFloat64("tolerance")
+		if len(args) > 2 {
+			var options []models.CreateOptionRequest
+			if err := json.Unmarshal([]byte(args[2]), &options); err != nil {
+				return err
+			}
+			req.Options = &options
+		}
+
+		b, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		r := bytes.NewReader(b)
+		resp, err := http.Post(endpoint("/quizzes/%s/questions", args[0]), "application/json", r)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 201 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		question, err := util.ReadBodyAndUnmarshal(models.Question{}, resp.Body)
+		if err != nil {
+			return err
+		}
+		log.Printf("QuestionID: %d", question.ID)
+		return nil
+	},
+}
+
+// seconds returns a duration flag such as --time-limit in whole seconds as the server expects it
+func seconds(cmd *cobra.Command, name string) uint32 {
+	d, _ := cmd.Flags().GetDuration(name)
+	return uint32(d / time.Second)
+}
+
 func init() {
 	rootCmd.AddCommand(createCmd)
 	createCmd.AddCommand(createUserCmd)
 	createCmd.AddCommand(createQuizCmd)
+	createCmd.AddCommand(createQuestionCmd)
 	createCmd.AddCommand(createOptionCmd)
+	createOptionCmd.Flags().String("pair", "", "Item the option is matched with when the question is a matching question")
+	createQuizCmd.Flags().Bool("shuffle-questions", false, "Give every attempt its own random order of questions")
+	createQuizCmd.Flags().Bool("shuffle-options", false, "Give every attempt its own random order of options")
+	createQuizCmd.Flags().Float32("wrong-penalty", 0, "Share of its points a question costs when it is answered wrong")
+	createQuizCmd.Flags().Float32("unanswered-penalty", 0, "Share of its points a question costs when it is left unanswered")
+	createQuizCmd.Flags().Float32("pass-threshold", 0, "Percentage a score needs to pass, every score passes by default")
+	createQuizCmd.Flags().Duration("time-limit", 0, "Time an attempt can take before it is submitted on its own, e.g. 30m, no limit by default")
+	createQuizCmd.Flags().Uint32("max-attempts", 0, "How many times a user can take the quiz, no limit by default")
+	createQuizCmd.Flags().Duration("cooldown", 0, "Time a user waits after an attempt finished before the next one can begin, e.g. 24h")
+	createQuizCmd.Flags().String("score-policy", "", "Which score of a user counts: best, latest, average or first, best by default")
+	createQuestionCmd.Flags().String("type", "", "Type of the question: single, multiple, text, numeric, ordering or matching, single by default")
+	createQuestionCmd.Flags().String("scoring", "", "Scoring of a multiple, ordering or matching question: all_or_nothing or partial, all_or_nothing by default")
+	createQuestionCmd.Flags().Float32("penalty", 1, "Share of the credit lost under partial scoring when every wrong option is picked")
+	createQuestionCmd.Flags().Float32("points", 1, "Points the question is worth")
+	createQuestionCmd.Flags().Duration("time-limit", 0, "Time the question can be answered in once it is shown, e.g. 45s, no limit by default")
+	createQuestionCmd.Flags().StringArray("accepted", nil, "Accepted answer of a text question, repeat for every accepted answer")
+	createQuestionCmd.Flags().String("matching", "", "How text answers are compared: exact or regex, exact by default")
+	createQuestionCmd.Flags().Bool("case-sensitive", false, "Tell upper and lower case apart in text answers")
+	createQuestionCmd.Flags().Float64("numeric", 0, "Expected answer of a numeric question")
+	createQuestionCmd.Flags().Float64("tolerance", 0, "How far a numeric answer may be from the expected one")
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
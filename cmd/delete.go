@@ -0,0 +1,66 @@
+/*
+Copyright © 2024 Serdil Cagin Cakmak serdilcakmak@gmail.com
+*/
+package cmd
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/lghtr35/quiz-maker/util"
+	"github.com/spf13/cobra"
+)
+
+// deleteCmd represents the delete command
+var deleteCmd = &cobra.Command{
+	Use:   "delete [TYPE] [Id]",
+	Short: "Delete can be used to remove entities from system",
+}
+
+var deleteQuestionCmd = &cobra.Command{
+	Use:   "question [Id]",
+	Short: "Delete a question with its options. Takers see the change once the quiz is published again",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("delete question called")
+		return deleteByID(endpoint("/quizzes/questions/%s", args[0]), args[0])
+	},
+}
+
+var deleteOptionCmd = &cobra.Command{
+	Use:   "option [Id]",
+	Short: "Delete an option. Takers see the change once the quiz is published again",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("delete option called")
+		return deleteByID(endpoint("/quizzes/options/%s", args[0]), args[0])
+	},
+}
+
+// deleteByID sends a DELETE request to url after checking id is a valid id
+func deleteByID(url string, id string) error {
+	if _, err := strconv.ParseUint(id, 10, 32); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 204 {
+		return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+	}
+	log.Printf("Deleted %s", id)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(deleteCmd)
+	deleteCmd.AddCommand(deleteQuestionCmd)
+	deleteCmd.AddCommand(deleteOptionCmd)
+}
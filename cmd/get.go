@@ -4,9 +4,10 @@ Copyright © 2024 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"fmt"
+	"encoding/json"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/lghtr35/quiz-maker/models"
@@ -31,20 +32,94 @@ var getQuizCmd = &cobra.Command{
 			return err
 		}
 
-		resp, err := http.Get(fmt.Sprintf("http://localhost:8080/quizzes/%s", args[0]))
+		resp, err := http.Get(endpoint("/quizzes/%s", args[0]))
 		if err != nil {
 			return err
 		}
 
 		if resp.StatusCode != 200 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
-		}
-		return util.ReadBodyAndPrintJSON[models.Quiz](resp.Body)
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		// takers and authors get different views of the quiz so it is printed as it is
+		return util.ReadBodyAndPrintJSON[json.RawMessage](resp.Body)
+	},
+}
+
+var getQuizzesCmd = &cobra.Command{
+	Use:   "quizzes",
+	Short: "List quizzes page by page, optionally filtered by name or ids",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("get quizzes called")
+
+		query := url.Values{}
+		if name, _ := cmd.Flags().GetString("name"); name != "" {
+			query.Set("name", name)
+		}
+		ids, _ := cmd.Flags().GetUintSlice("ids")
+		for _, id := range ids {
+			query.Add("idList", strconv.FormatUint(uint64(id), 10))
+		}
+		if status, _ := cmd.Flags().GetString("status"); status != "" {
+			query.Set("status", status)
+		}
+		if sort, _ := cmd.Flags().GetString("sort"); sort != "" {
+			query.Set("sort", sort)
+		}
+		if page, _ := cmd.Flags().GetUint32("page"); page != 0 {
+			query.Set("page", strconv.FormatUint(uint64(page), 10))
+		}
+		if size, _ := cmd.Flags().GetUint32("size"); size != 0 {
+			query.Set("size", strconv.FormatUint(uint64(size), 10))
+		}
+
+		resp, err := http.Get(endpoint("/quizzes?%s", query.Encode()))
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != 200 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		return util.ReadBodyAndPrintJSON[models.PaginationResponse](resp.Body)
+	},
+}
+
+var getUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "List users page by page or with the cursor of a previous page, optionally filtered by name, role or ids",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("get users called")
+
+		query := url.Values{}
+		if name, _ := cmd.Flags().GetString("name"); name != "" {
+			query.Set("name", name)
+		}
+		ids, _ := cmd.Flags().GetUintSlice("ids")
+		for _, id := range ids {
+			query.Add("idList", strconv.FormatUint(uint64(id), 10))
+		}
+		if role, _ := cmd.Flags().GetString("role"); role != "" {
+			query.Set("role", role)
+		}
+		if cursor, _ := cmd.Flags().GetString("cursor"); cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		if page, _ := cmd.Flags().GetUint32("page"); page != 0 {
+			query.Set("page", strconv.FormatUint(uint64(page), 10))
+		}
+		if size, _ := cmd.Flags().GetUint32("size"); size != 0 {
+			query.Set("size", strconv.FormatUint(uint64(size), 10))
+		}
+
+		resp, err := http.Get(endpoint("/users?%s", query.Encode()))
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != 200 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		return util.ReadBodyAndPrintJSON[models.PaginationResponse](resp.Body)
 	},
 }
 
@@ -59,23 +134,86 @@ var getQuestionCmd = &cobra.Command{
 			return err
 		}
 
-		resp, err := http.Get(fmt.Sprintf("http://localhost:8080/quizzes/questions/%s", args[0]))
+		query := url.Values{}
+		if progression, _ := cmd.Flags().GetUint32("progression"); progression != 0 {
+			query.Set("progressionId", strconv.FormatUint(uint64(progression), 10))
+		}
+
+		resp, err := http.Get(endpoint("/quizzes/questions/%s?%s", args[0], query.Encode()))
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != 200 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		return util.ReadBodyAndPrintJSON[json.RawMessage](resp.Body)
+	},
+}
+
+var getVersionsCmd = &cobra.Command{
+	Use:   "versions [QuizId]",
+	Short: "List the versions of a quiz",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("get versions called")
+
+		if _, err := strconv.ParseUint(args[0], 10, 32); err != nil {
+			return err
+		}
+
+		resp, err := http.Get(endpoint("/quizzes/versions/%s", args[0]))
 		if err != nil {
 			return err
 		}
 
 		if resp.StatusCode != 200 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
-		}
-		return util.ReadBodyAndPrintJSON[models.Question](resp.Body)
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		return util.ReadBodyAndPrintJSON[[]models.QuizVersion](resp.Body)
 	},
 }
 
+var getVersionCmd = &cobra.Command{
+	Use:   "version [QuizId] [Number]",
+	Short: "Get a version of a quiz with the quiz as it was then",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("get version called")
+
+		if _, err := strconv.ParseUint(args[0], 10, 32); err != nil {
+			return err
+		}
+		if _, err := strconv.ParseUint(args[1], 10, 32); err != nil {
+			return err
+		}
+
+		resp, err := http.Get(endpoint("/quizzes/versions/%s/%s", args[0], args[1]))
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != 200 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		// like the quiz itself the version includes the answer key only for its author and admins
+		return util.ReadBodyAndPrintJSON[json.RawMessage](resp.Body)
+	},
+}
+
+// resultQuery selects the version of the quiz given with --version, every version when it is not set,
+// and the attempt given with --attempt on the commands that have it
+func resultQuery(cmd *cobra.Command) string {
+	query := url.Values{}
+	if version, _ := cmd.Flags().GetUint32("version"); version != 0 {
+		query.Set("version", strconv.FormatUint(uint64(version), 10))
+	}
+	if attempt, _ := cmd.Flags().GetUint32("attempt"); attempt != 0 {
+		query.Set("attempt", strconv.FormatUint(uint64(attempt), 10))
+	}
+	return query.Encode()
+}
+
 var getScore = &cobra.Command{
 	Use:   "score [UserId] [QuizId]",
 	Short: "Get score by UserId and QuizId",
@@ -90,18 +228,13 @@ var getScore = &cobra.Command{
 			return err
 		}
 
-		resp, err := http.Get(fmt.Sprintf("http://localhost:8080/users/%s/quiz/%s", args[0], args[1]))
+		resp, err := http.Get(endpoint("/users/%s/quiz/%s?%s", args[0], args[1], resultQuery(cmd)))
 		if err != nil {
 			return err
 		}
 
 		if resp.StatusCode != 200 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
 		}
 		return util.ReadBodyAndPrintJSON[models.Score](resp.Body)
 	},
@@ -121,18 +254,13 @@ var getRanking = &cobra.Command{
 			return err
 		}
 
-		resp, err := http.Get(fmt.Sprintf("http://localhost:8080/users/%s/quiz/%s/ranking", args[0], args[1]))
+		resp, err := http.Get(endpoint("/users/%s/quiz/%s/ranking?%s", args[0], args[1], resultQuery(cmd)))
 		if err != nil {
 			return err
 		}
 
 		if resp.StatusCode != 200 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
 		}
 		return util.ReadBodyAndPrintJSON[models.ReadUserRankingByScoreResponse](resp.Body)
 	},
@@ -152,30 +280,48 @@ var getScoreAnalysis = &cobra.Command{
 			return err
 		}
 
-		resp, err := http.Get(fmt.Sprintf("http://localhost:8080/users/%s/quiz/%s/analysis", args[0], args[1]))
+		resp, err := http.Get(endpoint("/users/%s/quiz/%s/analysis?%s", args[0], args[1], resultQuery(cmd)))
 		if err != nil {
 			return err
 		}
 
 		if resp.StatusCode != 200 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
-		}
-		return util.ReadBodyAndPrintJSON[models.ReadUserScoreAnalysis](resp.Body)
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		// takers get the analysis without the answer key so it is printed as it is
+		return util.ReadBodyAndPrintJSON[json.RawMessage](resp.Body)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(getCmd)
 	getCmd.AddCommand(getQuizCmd)
+	getCmd.AddCommand(getQuizzesCmd)
+	getCmd.AddCommand(getUsersCmd)
 	getCmd.AddCommand(getQuestionCmd)
+	getCmd.AddCommand(getVersionsCmd)
+	getCmd.AddCommand(getVersionCmd)
 	getCmd.AddCommand(getScore)
 	getCmd.AddCommand(getRanking)
 	getCmd.AddCommand(getScoreAnalysis)
+	getQuizzesCmd.Flags().String("name", "", "Only list quizzes whose name contains this text")
+	getQuizzesCmd.Flags().UintSlice("ids", nil, "Only list quizzes with these ids, e.g. --ids 1,2,3")
+	getQuizzesCmd.Flags().String("status", "", "Only list quizzes with this status: draft, published or archived")
+	getQuizzesCmd.Flags().String("sort", "", "Sort by id, name or createdAt, prefix with - for descending order")
+	getQuizzesCmd.Flags().Uint32("page", 0, "Page to list, starts at 1")
+	getQuizzesCmd.Flags().Uint32("size", 0, "Quizzes per page, 20 by default and 100 at most")
+	getUsersCmd.Flags().String("name", "", "Only list users whose name contains this text")
+	getUsersCmd.Flags().UintSlice("ids", nil, "Only list users with these ids, e.g. --ids 1,2,3")
+	getUsersCmd.Flags().String("role", "", "Only list users with this role: admin, author or taker")
+	getUsersCmd.Flags().String("cursor", "", "nextCursor of a previous page, used instead of --page")
+	getUsersCmd.Flags().Uint32("page", 0, "Page to list, starts at 1")
+	getUsersCmd.Flags().Uint32("size", 0, "Users per page, 20 by default and 100 at most")
+	getQuestionCmd.Flags().Uint32("progression", 0, "Order the options the way this progression of yours sees them")
+	getScore.Flags().Uint32("version", 0, "Only consider scores on this version of the quiz")
+	getRanking.Flags().Uint32("version", 0, "Only rank scores on this version of the quiz")
+	getScoreAnalysis.Flags().Uint32("version", 0, "Analyse the score on this version of the quiz")
+	getScore.Flags().Uint32("attempt", 0, "Get the score of this attempt instead of the one the score policy of the quiz counts")
+	getScoreAnalysis.Flags().Uint32("attempt", 0, "Analyse this attempt instead of the one the score policy of the quiz counts")
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
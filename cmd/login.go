@@ -0,0 +1,123 @@
+/*
+Copyright © 2024 Serdil Cagin Cakmak serdilcakmak@gmail.com
+*/
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/util"
+	"github.com/spf13/cobra"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login [Name] [Password]",
+	Short: "Log in as a user",
+	Long: `Log in with the name and password of a user. The session token is saved in the user config directory
+and sent by every following command until it expires or logout is called.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("login called")
+
+		req := models.LoginRequest{
+			Name:     args[0],
+			Password: args[1],
+		}
+		b, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		r := bytes.NewReader(b)
+		resp, err := http.Post(endpoint("/auth/login"), "application/json", r)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 200 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		login, err := util.ReadBodyAndUnmarshal(models.LoginResponse{}, resp.Body)
+		if err != nil {
+			return err
+		}
+
+		path, err := tokenFile()
+		if err != nil {
+			return err
+		}
+		if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return err
+		}
+		if err = os.WriteFile(path, []byte(login.Token), 0o600); err != nil {
+			return err
+		}
+		log.Printf("Logged in as %s (UserID: %d) until %s", login.User.Name, login.User.ID, login.ExpiresAt)
+		return nil
+	},
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Forget the session token saved by login",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("logout called")
+
+		path, err := tokenFile()
+		if err != nil {
+			return err
+		}
+		if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	},
+}
+
+// tokenFile is where login saves the session token
+func tokenFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "quiz-maker", "token"), nil
+}
+
+// authorize makes every request of the commands carry the session token,
+// taken from --token, QUIZ_MAKER_TOKEN or the config file and otherwise from the last login
+func authorize(token string) {
+	if token == "" {
+		if path, err := tokenFile(); err == nil {
+			if b, err := os.ReadFile(path); err == nil {
+				token = strings.TrimSpace(string(b))
+			}
+		}
+	}
+	if token == "" {
+		return
+	}
+	http.DefaultClient.Transport = bearerTransport{token: token, next: http.DefaultTransport}
+}
+
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t bearerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(r)
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
+}
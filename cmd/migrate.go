@@ -0,0 +1,118 @@
+/*
+Copyright © 2024 Serdil Cagin Cakmak serdilcakmak@gmail.com
+*/
+package cmd
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/lghtr35/quiz-maker/config"
+	"github.com/lghtr35/quiz-maker/database"
+	"github.com/lghtr35/quiz-maker/migrations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [COMMAND]",
+	Short: "Apply, roll back or list database schema migrations",
+	Long:  `Apply, roll back or list database schema migrations. Applied migrations are recorded in the schema_migrations table of the selected database.`,
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply every pending migration",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Open(cfg.Database.Driver, cfg.Database.DSN)
+		if err != nil {
+			return err
+		}
+
+		applied, err := migrations.Up(db)
+		for _, m := range applied {
+			log.Printf("Applied %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			log.Println("Database is up to date")
+		}
+		return nil
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [Steps]",
+	Short: "Roll back the last applied migrations. Steps defaults to 1.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		steps := 1
+		if len(args) > 0 {
+			s, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			steps = s
+		}
+
+		db, err := database.Open(cfg.Database.Driver, cfg.Database.DSN)
+		if err != nil {
+			return err
+		}
+
+		rolledBack, err := migrations.Down(db, steps)
+		for _, m := range rolledBack {
+			log.Printf("Rolled back %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			log.Println("No applied migrations to roll back")
+		}
+		return nil
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List every migration and whether it has been applied",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Open(cfg.Database.Driver, cfg.Database.DSN)
+		if err != nil {
+			return err
+		}
+
+		statuses, err := migrations.Status(db)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			if s.Applied {
+				log.Printf("%04d_%s applied at %s", s.Version, s.Name, s.AppliedAt.Format("2006-01-02 15:04:05"))
+			} else {
+				log.Printf("%04d_%s pending", s.Version, s.Name)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+
+	addDatabaseFlags(migrateCmd.PersistentFlags())
+}
+
+// addDatabaseFlags registers the flags selecting the database shared by serve, migrate and admin
+func addDatabaseFlags(flags *pflag.FlagSet) {
+	defaults := config.Default()
+	flags.String("driver", defaults.Database.Driver, "Database driver to use: sqlite, postgres or mysql (env QUIZ_MAKER_DATABASE_DRIVER)")
+	flags.String("dsn", defaults.Database.DSN, "Data source name of the database, defaults to quiz-maker.db for sqlite (env QUIZ_MAKER_DATABASE_DSN)")
+}
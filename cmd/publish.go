@@ -0,0 +1,59 @@
+/*
+Copyright © 2024 Serdil Cagin Cakmak serdilcakmak@gmail.com
+*/
+package cmd
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/util"
+	"github.com/spf13/cobra"
+)
+
+// publishCmd represents the publish command
+var publishCmd = &cobra.Command{
+	Use:   "publish [QuizId]",
+	Short: "Make a quiz available to takers",
+	Long: `Publish checks that every question of a quiz can be answered and scored and makes the quiz available to takers.
+The quiz as it is now is saved as a new version, edits made afterwards reach takers once the quiz is published again.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("publish called")
+		return changeStatus(endpoint("/quizzes/%s/publish", args[0]), args[0])
+	},
+}
+
+// archiveCmd represents the archive command
+var archiveCmd = &cobra.Command{
+	Use:   "archive [QuizId]",
+	Short: "Stop a quiz from being begun, attempts already begun can still be submitted",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("archive called")
+		return changeStatus(endpoint("/quizzes/%s/archive", args[0]), args[0])
+	},
+}
+
+// changeStatus sends a POST request without a body to url after checking id is a valid id
+func changeStatus(url string, id string) error {
+	if _, err := strconv.ParseUint(id, 10, 32); err != nil {
+		return err
+	}
+
+	resp, err := http.Post(url, "application/json", nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 200 {
+		return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+	}
+	return util.ReadBodyAndPrintJSON[models.Quiz](resp.Body)
+}
+
+func init() {
+	rootCmd.AddCommand(publishCmd)
+	rootCmd.AddCommand(archiveCmd)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2024 Serdil Cagin Cakmak serdilcakmak@gmail.com
+*/
+package cmd
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/util"
+	"github.com/spf13/cobra"
+)
+
+// reorderCmd represents the reorder command
+var reorderCmd = &cobra.Command{
+	Use:   "reorder [TYPE] [ParentId] [Ids...]",
+	Short: "Reorder the questions of a quiz or the options of a question",
+}
+
+var reorderQuestionsCmd = &cobra.Command{
+	Use:   "questions [QuizId] [QuestionIds...]",
+	Short: "Arrange every question of a quiz in the given order",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("reorder questions called")
+		return reorder(endpoint("/quizzes/%s/questions/order", args[0]), args)
+	},
+}
+
+var reorderOptionsCmd = &cobra.Command{
+	Use:   "options [QuestionId] [OptionIds...]",
+	Short: "Arrange every option of a question in the given order",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("reorder options called")
+		return reorder(endpoint("/quizzes/questions/%s/options/order", args[0]), args)
+	},
+}
+
+// reorder sends the ids following the parent id in args as the new order to url
+func reorder(url string, args []string) error {
+	if _, err := strconv.ParseUint(args[0], 10, 32); err != nil {
+		return err
+	}
+	var req models.ReorderRequest
+	for _, arg := range args[1:] {
+		id, err := strconv.ParseUint(arg, 10, 32)
+		if err != nil {
+			return err
+		}
+		req.IDs = append(req.IDs, uint32(id))
+	}
+
+	resp, err := sendJSON(http.MethodPut, url, req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 200 {
+		return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+	}
+	log.Printf("Reordered: %v", req.IDs)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(reorderCmd)
+	reorderCmd.AddCommand(reorderQuestionsCmd)
+	reorderCmd.AddCommand(reorderOptionsCmd)
+}
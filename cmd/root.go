@@ -6,14 +6,35 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/lghtr35/quiz-maker/config"
 	"github.com/spf13/cobra"
 )
 
+var (
+	configFile string
+	cfg        *config.Config
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "quiz-maker [COMMAND]",
 	Short: "Quiz-Maker is a simple Quiz api that serves quizzes",
-	Long:  `Quiz-Maker is a simple Quiz api that serves quizzes with multiple choice answered questions and calculates score and ranks users.`,
+	Long: `Quiz-Maker is a simple Quiz api that serves quizzes with multiple choice answered questions and calculates score and ranks users.
+
+Settings are read from a YAML config file (--config, defaults to quiz-maker.yaml in the working directory when present),
+then from QUIZ_MAKER_* environment variables and finally from command line flags.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		cfg, err = config.Load(configFile)
+		if err != nil {
+			return err
+		}
+		applyFlags(cmd, cfg)
+		authorize(cfg.Client.Token)
+		return nil
+	},
 }
 
 func Execute() {
@@ -22,3 +43,48 @@ func Execute() {
 		os.Exit(1)
 	}
 }
+
+// applyFlags overrides the config with the flags that were given on the command line
+func applyFlags(cmd *cobra.Command, cfg *config.Config) {
+	flags := map[string]*string{
+		"server":   &cfg.Client.Server,
+		"token":    &cfg.Client.Token,
+		"address":  &cfg.Server.Address,
+		"base-url": &cfg.Server.BaseURL,
+		"driver":   &cfg.Database.Driver,
+		"dsn":      &cfg.Database.DSN,
+	}
+	for name, target := range flags {
+		if cmd.Flags().Changed(name) {
+			*target, _ = cmd.Flags().GetString(name)
+		}
+	}
+
+	durationFlags := map[string]*time.Duration{
+		"read-timeout":        &cfg.Server.ReadTimeout,
+		"read-header-timeout": &cfg.Server.ReadHeaderTimeout,
+		"write-timeout":       &cfg.Server.WriteTimeout,
+		"idle-timeout":        &cfg.Server.IdleTimeout,
+		"shutdown-timeout":    &cfg.Server.ShutdownTimeout,
+		"token-ttl":           &cfg.Auth.TokenTTL,
+		"expiry-interval":     &cfg.Expiry.Interval,
+		"expiry-inactivity":   &cfg.Expiry.Inactivity,
+	}
+	for name, target := range durationFlags {
+		if cmd.Flags().Changed(name) {
+			*target, _ = cmd.Flags().GetDuration(name)
+		}
+	}
+}
+
+// endpoint builds the URL of an API path on the server the CLI talks to
+func endpoint(format string, args ...any) string {
+	return strings.TrimSuffix(cfg.Client.Server, "/") + fmt.Sprintf(format, args...)
+}
+
+func init() {
+	defaults := config.Default()
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Path of the YAML config file, defaults to quiz-maker.yaml when present")
+	rootCmd.PersistentFlags().String("server", defaults.Client.Server, "Base URL of the quiz-maker server the commands talk to (env QUIZ_MAKER_SERVER)")
+	rootCmd.PersistentFlags().String("token", "", "Session token sent with every request, defaults to the one saved by login (env QUIZ_MAKER_TOKEN)")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lghtr35/quiz-maker/config"
+	"github.com/spf13/cobra"
+)
+
+func TestSettingsPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quiz-maker.yaml")
+	file := `
+server:
+  address: ":7000"
+  baseUrl: "http://file.example"
+database:
+  driver: postgres
+  dsn: "host=file"
+client:
+  server: "http://file:7000"
+`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.EnvPrefix+"SERVER_BASE_URL", "http://env.example")
+	t.Setenv(config.EnvPrefix+"DATABASE_DSN", "host=env")
+	t.Setenv(config.EnvPrefix+"SERVER", "http://env:7000")
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("server", "", "")
+	cmd.Flags().String("address", "", "")
+	cmd.Flags().String("base-url", "", "")
+	addDatabaseFlags(cmd.Flags())
+	if err := cmd.ParseFlags([]string{"--dsn", "host=flag", "--server", "http://flag:7000"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyFlags(cmd, cfg)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"file over default", cfg.Server.Address, ":7000"},
+		{"file over default", cfg.Database.Driver, "postgres"},
+		{"env over file", cfg.Server.BaseURL, "http://env.example"},
+		{"flag over env", cfg.Database.DSN, "host=flag"},
+		{"flag over env", cfg.Client.Server, "http://flag:7000"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
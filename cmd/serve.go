@@ -4,57 +4,178 @@ Copyright © 2024 Serdil Cagin Cakmak serdilcakmak@gmail.com
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	_ "github.com/lghtr35/quiz-maker/docs"
+	"github.com/lghtr35/quiz-maker/auth"
+	"github.com/lghtr35/quiz-maker/config"
+	"github.com/lghtr35/quiz-maker/database"
+	"github.com/lghtr35/quiz-maker/docs"
 	"github.com/lghtr35/quiz-maker/handlers"
-	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/migrations"
+	"github.com/lghtr35/quiz-maker/service"
+	"github.com/lghtr35/quiz-maker/store"
 	"github.com/spf13/cobra"
 	httpSwagger "github.com/swaggo/http-swagger"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
 )
 
+var migrate bool
+
 // serveCmd represents the serve command
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Starts to listen for connections",
-	Run: func(cmd *cobra.Command, args []string) {
-		db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
-		if err != nil {
-			panic(err)
-		}
-
-		err = db.AutoMigrate(
-			&models.User{},
-			&models.Quiz{},
-			&models.Question{},
-			&models.Progression{},
-			&models.Option{},
-			&models.Score{},
-			&models.Answer{},
-		)
+	Long: `Starts to listen for connections on --address. The backing store is chosen with --driver (sqlite, postgres or mysql) and --dsn.
+Without a DSN the sqlite driver keeps its data in quiz-maker.db in the working directory.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Open(cfg.Database.Driver, cfg.Database.DSN)
 		if err != nil {
-			panic(err)
+			return err
+		}
+		defer func() {
+			if err := database.Close(db); err != nil {
+				log.Printf("Closing database failed: %v", err)
+			}
+		}()
+
+		if migrate {
+			applied, err := migrations.Up(db)
+			if err != nil {
+				return err
+			}
+			for _, m := range applied {
+				log.Printf("Applied migration %04d_%s", m.Version, m.Name)
+			}
+		} else {
+			pending, err := migrations.Pending(db)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("database has %d pending migrations, run quiz-maker migrate up first", len(pending))
+			}
+		}
+
+		s := store.NewGormStore(db)
+		secret := []byte(cfg.Auth.Secret)
+		if len(secret) == 0 {
+			log.Println("No auth secret configured, generating one. Session tokens will not survive a restart")
+			if secret, err = auth.RandomSecret(); err != nil {
+				return err
+			}
 		}
+		authService := service.NewAuthService(s, auth.NewSigner(secret, cfg.Auth.TokenTTL))
 
-		handlers := handlers.InitializeHandlers(db)
+		health := handlers.NewHealthHandler(s)
+		apiHandlers := handlers.InitializeHandlers(service.NewQuizService(s), service.NewUserService(s), authService)
 
 		mux := http.NewServeMux()
 
+		publicURL := cfg.Server.PublicURL()
+		if u, err := url.Parse(publicURL); err == nil {
+			docs.SwaggerInfo.Host = u.Host
+		}
 		mux.HandleFunc("GET /swagger/", httpSwagger.Handler(
-			httpSwagger.URL("http://localhost:8080/swagger/doc.json"), //The url pointing to API definition
+			httpSwagger.URL(publicURL+"/swagger/doc.json"), //The url pointing to API definition
 		))
-		for _, h := range handlers {
+		mux = health.ConfigureSelf(mux)
+		for _, h := range apiHandlers {
 			mux = h.ConfigureSelf(mux)
 		}
 
-		log.Println("Started listening on port 8080")
-		log.Fatal(http.ListenAndServe(":8080", mux))
+		server := &http.Server{
+			Addr:              cfg.Server.Address,
+			Handler:           handlers.Authenticate(authService, mux),
+			ReadTimeout:       cfg.Server.ReadTimeout,
+			ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+			WriteTimeout:      cfg.Server.WriteTimeout,
+			IdleTimeout:       cfg.Server.IdleTimeout,
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// The expiry worker is stopped before the database is closed
+		if cfg.Expiry.Interval > 0 {
+			expiryCtx, stopExpiry := context.WithCancel(ctx)
+			expiryDone := make(chan struct{})
+			go func() {
+				defer close(expiryDone)
+				expireProgressions(expiryCtx, service.NewExpiryService(s, cfg.Expiry.Inactivity), cfg.Expiry.Interval)
+			}()
+			defer func() {
+				stopExpiry()
+				<-expiryDone
+			}()
+		}
+
+		serveErr := make(chan error, 1)
+		go func() {
+			log.Printf("Started listening on %s, serving at %s", cfg.Server.Address, publicURL)
+			serveErr <- server.ListenAndServe()
+		}()
+
+		select {
+		case err := <-serveErr:
+			return err
+		case <-ctx.Done():
+		}
+
+		// Stop advertising readiness and give in-flight requests time to finish before closing the database
+		log.Printf("Shutting down, draining requests for up to %s", cfg.Server.ShutdownTimeout)
+		health.SetReady(false)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("graceful shutdown failed: %w", err)
+		}
+		log.Println("Server stopped")
+		return nil
 	},
 }
 
+// expireProgressions sweeps stale progressions every interval until ctx is done
+func expireProgressions(ctx context.Context, expiry *service.ExpiryService, interval time.Duration) {
+	log.Printf("Expiring stale progressions every %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			expired, err := expiry.Sweep(ctx, now)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("Expiring progressions failed: %v", err)
+			}
+			if expired.Submitted > 0 || expired.TimedOut > 0 || expired.Expired > 0 {
+				log.Printf("Closed %d answered progressions, %d that timed out and %d inactive ones", expired.Submitted, expired.TimedOut, expired.Expired)
+			}
+		}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(serveCmd)
+
+	defaults := config.Default()
+	serveCmd.Flags().String("address", defaults.Server.Address, "Address to listen on (env QUIZ_MAKER_SERVER_ADDRESS)")
+	serveCmd.Flags().String("base-url", "", "Public base URL of the server, derived from --address when empty (env QUIZ_MAKER_SERVER_BASE_URL)")
+	serveCmd.Flags().Duration("read-timeout", defaults.Server.ReadTimeout, "Maximum duration for reading a whole request (env QUIZ_MAKER_SERVER_READ_TIMEOUT)")
+	serveCmd.Flags().Duration("read-header-timeout", defaults.Server.ReadHeaderTimeout, "Maximum duration for reading request headers (env QUIZ_MAKER_SERVER_READ_HEADER_TIMEOUT)")
+	serveCmd.Flags().Duration("write-timeout", defaults.Server.WriteTimeout, "Maximum duration before timing out writes of a response (env QUIZ_MAKER_SERVER_WRITE_TIMEOUT)")
+	serveCmd.Flags().Duration("idle-timeout", defaults.Server.IdleTimeout, "Maximum duration to keep idle keep-alive connections open (env QUIZ_MAKER_SERVER_IDLE_TIMEOUT)")
+	serveCmd.Flags().Duration("shutdown-timeout", defaults.Server.ShutdownTimeout, "Maximum duration to drain in-flight requests on SIGINT or SIGTERM (env QUIZ_MAKER_SERVER_SHUTDOWN_TIMEOUT)")
+	serveCmd.Flags().Duration("token-ttl", defaults.Auth.TokenTTL, "How long session tokens issued by login stay valid, the signing secret is read from QUIZ_MAKER_AUTH_SECRET or the config file (env QUIZ_MAKER_AUTH_TOKEN_TTL)")
+	serveCmd.Flags().Duration("expiry-interval", defaults.Expiry.Interval, "How often progressions that were never submitted are checked for expiry, 0 turns expiry off (env QUIZ_MAKER_EXPIRY_INTERVAL)")
+	serveCmd.Flags().Duration("expiry-inactivity", defaults.Expiry.Inactivity, "How long a progression can go without an answer before it expires, 0 only closes progressions past their deadline (env QUIZ_MAKER_EXPIRY_INACTIVITY)")
+	addDatabaseFlags(serveCmd.Flags())
+	serveCmd.Flags().BoolVar(&migrate, "migrate", true, "Apply pending migrations on start, when disabled serve refuses to start with pending migrations")
 }
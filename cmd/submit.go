@@ -37,17 +37,12 @@ var submitCmd = &cobra.Command{
 			return err
 		}
 		r := bytes.NewReader(b)
-		resp, err := http.Post("http://localhost:8080/quizzes/submit", "application/json", r)
+		resp, err := http.Post(endpoint("/quizzes/submit"), "application/json", r)
 		if err != nil {
 			return err
 		}
 		if resp.StatusCode != 200 {
-			s, err := util.ReadBodyAndGetString(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("Status: %d, Error: %s", resp.StatusCode, s)
-			return nil
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
 		}
 		unmarshalled, err := util.ReadBodyAndUnmarshal(models.FinalizeQuizResponse{}, resp.Body)
 		if err != nil {
@@ -0,0 +1,283 @@
+/*
+Copyright © 2024 Serdil Cagin Cakmak serdilcakmak@gmail.com
+*/
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/util"
+	"github.com/spf13/cobra"
+)
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:   "update [TYPE] [ARGUMENTS]",
+	Short: "Update can be used to change entities in system. Only the given flags are changed",
+}
+
+var updateUserCmd = &cobra.Command{
+	Use:   "user [Id]",
+	Short: "Update the name, password or role of a user. Only admins can change roles",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("update user called")
+
+		id, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return err
+		}
+		req := models.UpdateUserRequest{
+			ID: uint32(id),
+		}
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			req.Name = &name
+		}
+		if cmd.Flags().Changed("password") {
+			password, _ := cmd.Flags().GetString("password")
+			req.Password = &password
+		}
+		if cmd.Flags().Changed("role") {
+			role, _ := cmd.Flags().GetString("role")
+			req.Role = &role
+		}
+
+		resp, err := sendJSON(http.MethodPatch, endpoint("/users"), req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 200 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		return util.ReadBodyAndPrintJSON[models.User](resp.Body)
+	},
+}
+
+var updateQuizCmd = &cobra.Command{
+	Use:   "quiz [Id]",
+	Short: "Update the name, the shuffle, scoring or attempt settings of a quiz. Takers get shuffle and scoring changes once the quiz is published again",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("update quiz called")
+
+		id, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return err
+		}
+		req := models.UpdateQuizRequest{
+			ID: uint32(id),
+		}
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			req.Name = &name
+		}
+		if cmd.Flags().Changed("shuffle-questions") {
+			shuffle, _ := cmd.Flags().GetBool("shuffle-questions")
+			req.ShuffleQuestions = &shuffle
+		}
+		if cmd.Flags().Changed("shuffle-options") {
+			shuffle, _ := cmd.Flags().GetBool("shuffle-options")
+			req.ShuffleOptions = &shuffle
+		}
+		if cmd.Flags().Changed("wrong-penalty") {
+			penalty, _ := cmd.Flags().GetFloat32("wrong-penalty")
+			req.WrongPenalty = &penalty
+		}
+		if cmd.Flags().Changed("unanswered-penalty") {
+			penalty, _ := cmd.Flags().GetFloat32("unanswered-penalty")
+			req.UnansweredPenalty = &penalty
+		}
+		if cmd.Flags().Changed("pass-threshold") {
+			threshold, _ := cmd.Flags().GetFloat32("pass-threshold")
+			req.PassThreshold = &threshold
+		}
+		if cmd.Flags().Changed("time-limit") {
+			limit := seconds(cmd, "time-limit")
+			req.TimeLimit = &limit
+		}
+		if cmd.Flags().Changed("max-attempts") {
+			attempts, _ := cmd.Flags().GetUint32("max-attempts")
+			req.MaxAttempts = &attempts
+		}
+		if cmd.Flags().Changed("cooldown") {
+			cooldown := seconds(cmd, "cooldown")
+			req.Cooldown = &cooldown
+		}
+		if cmd.Flags().Changed("score-policy") {
+			policy, _ := cmd.Flags().GetString("score-policy")
+			req.ScorePolicy = &policy
+		}
+
+		resp, err := sendJSON(http.MethodPatch, endpoint("/quizzes"), req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 200 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		return util.ReadBodyAndPrintJSON[models.Quiz](resp.Body)
+	},
+}
+
+var updateQuestionCmd = &cobra.Command{
+	Use:   "question [Id]",
+	Short: "Update the text, type, scoring or answer key of a question. Takers see the change once the quiz is published again",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("update question called")
+
+		if _, err := strconv.ParseUint(args[0], 10, 32); err != nil {
+			return err
+		}
+		var req models.UpdateQuestionRequest
+		if cmd.Flags().Changed("question") {
+			question, _ := cmd.Flags().GetString("question")
+			req.Question = &question
+		}
+		if cmd.Flags().Changed("type") {
+			questionType, _ := cmd.Flags().GetString("type")
+			req.Type = &questionType
+		}
+		if cmd.Flags().Changed("scoring") {
+			scoring, _ := cmd.Flags().GetString("scoring")
+			req.Scoring = &scoring
+		}
+		if cmd.Flags().Changed("penalty") {
+			penalty, _ := cmd.Flags().GetFloat32("penalty")
+			req.Penalty = &penalty
+		}
+		if cmd.Flags().Changed("points") {
+			points, _ := cmd.Flags().GetFloat32("points")
+			req.Points = &points
+		}
+		if cmd.Flags().Changed("time-limit") {
+			limit := seconds(cmd, "time-limit")
+			req.TimeLimit = &limit
+		}
+		if cmd.Flags().Changed("accepted") {
+			accepted, _ := cmd.Flags().GetStringArray("accepted")
+			req.AcceptedAnswers = &accepted
+		}
+		if cmd.Flags().Changed("matching") {
+			matching, _ := cmd.Flags().GetString("matching")
+			req.Matching = &matching
+		}
+		if cmd.Flags().Changed("case-sensitive") {
+			caseSensitive, _ := cmd.Flags().GetBool("case-sensitive")
+			req.CaseSensitive = &caseSensitive
+		}
+		if cmd.Flags().Changed("numeric") {
+			numeric, _ := cmd.Flags().GetFloat64("numeric")
+			req.NumericAnswer = &numeric
+		}
+		if cmd.Flags().Changed("tolerance") {
+			tolerance, _ := cmd.Flags().GetFloat64("tolerance")
+			req.Tolerance = &tolerance
+		}
+
+		resp, err := sendJSON(http.MethodPatch, endpoint("/quizzes/questions/%s", args[0]), req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 200 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		return util.ReadBodyAndPrintJSON[models.Question](resp.Body)
+	},
+}
+
+var updateOptionCmd = &cobra.Command{
+	Use:   "option [Id]",
+	Short: "Update the value, correctness, step or pair of an option. Takers see the change once the quiz is published again",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Println("update option called")
+
+		if _, err := strconv.ParseUint(args[0], 10, 32); err != nil {
+			return err
+		}
+		var req models.UpdateOptionRequest
+		if cmd.Flags().Changed("value") {
+			value, _ := cmd.Flags().GetString("value")
+			req.Value = &value
+		}
+		if cmd.Flags().Changed("correct") {
+			correct, _ := cmd.Flags().GetBool("correct")
+			req.IsCorrect = &correct
+		}
+		if cmd.Flags().Changed("step") {
+			step, _ := cmd.Flags().GetInt("step")
+			req.Step = &step
+		}
+		if cmd.Flags().Changed("pair") {
+			pair, _ := cmd.Flags().GetString("pair")
+			req.Pair = &pair
+		}
+
+		resp, err := sendJSON(http.MethodPatch, endpoint("/quizzes/options/%s", args[0]), req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 200 {
+			return util.ReadBodyAndPrintError(resp.StatusCode, resp.Body)
+		}
+		return util.ReadBodyAndPrintJSON[models.Option](resp.Body)
+	},
+}
+
+// sendJSON sends v as the JSON body of a request with the given method, for the methods http.Post does not cover
+func sendJSON(method string, url string, v any) (*http.Response, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return http.DefaultClient.Do(req)
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+	updateCmd.AddCommand(updateUserCmd)
+	updateCmd.AddCommand(updateQuizCmd)
+	updateCmd.AddCommand(updateQuestionCmd)
+	updateCmd.AddCommand(updateOptionCmd)
+
+	updateUserCmd.Flags().String("name", "", "New name of the user")
+	updateUserCmd.Flags().String("password", "", "New password of the user")
+	updateUserCmd.Flags().String("role", "", "New role of the user: admin, author or taker")
+	updateQuizCmd.Flags().String("name", "", "New name of the quiz")
+	updateQuizCmd.Flags().Bool("shuffle-questions", false, "Whether every attempt gets its own order of questions, e.g. --shuffle-questions=false")
+	updateQuizCmd.Flags().Bool("shuffle-options", false, "Whether every attempt gets its own order of options, e.g. --shuffle-options=false")
+	updateQuizCmd.Flags().Float32("wrong-penalty", 0, "New share of its points a question costs when it is answered wrong")
+	updateQuizCmd.Flags().Float32("unanswered-penalty", 0, "New share of its points a question costs when it is left unanswered")
+	updateQuizCmd.Flags().Float32("pass-threshold", 0, "New percentage a score needs to pass")
+	updateQuizCmd.Flags().Duration("time-limit", 0, "New time an attempt can take, 0 for no limit")
+	updateQuizCmd.Flags().Uint32("max-attempts", 0, "New number of times a user can take the quiz, 0 for no limit")
+	updateQuizCmd.Flags().Duration("cooldown", 0, "New time a user waits after an attempt finished before the next one can begin, 0 for none")
+	updateQuizCmd.Flags().String("score-policy", "", "New score of a user that counts: best, latest, average or first")
+	updateQuestionCmd.Flags().String("question", "", "New text of the question")
+	updateQuestionCmd.Flags().String("type", "", "New type of the question: single, multiple, text, numeric, ordering or matching, only while it has no options")
+	updateQuestionCmd.Flags().String("scoring", "", "New scoring of the question: all_or_nothing or partial")
+	updateQuestionCmd.Flags().Float32("penalty", 1, "New share of the credit lost under partial scoring when every wrong option is picked")
+	updateQuestionCmd.Flags().Float32("points", 1, "New points the question is worth")
+	updateQuestionCmd.Flags().Duration("time-limit", 0, "New time the question can be answered in, 0 for no limit")
+	updateQuestionCmd.Flags().StringArray("accepted", nil, "New accepted answers of a text question, repeat for every accepted answer")
+	updateQuestionCmd.Flags().String("matching", "", "New way text answers are compared: exact or regex")
+	updateQuestionCmd.Flags().Bool("case-sensitive", false, "Whether text answers tell upper and lower case apart, e.g. --case-sensitive=false")
+	updateQuestionCmd.Flags().Float64("numeric", 0, "New expected answer of a numeric question")
+	updateQuestionCmd.Flags().Float64("tolerance", 0, "New distance a numeric answer may be from the expected one")
+	updateOptionCmd.Flags().String("value", "", "New value of the option")
+	updateOptionCmd.Flags().Bool("correct", false, "Whether the option is correct, e.g. --correct=false")
+	updateOptionCmd.Flags().Int("step", 0, "New place of the option in the correct order of an ordering question")
+	updateOptionCmd.Flags().String("pair", "", "New item the option is matched with in a matching question")
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is read from the working directory when no config file is given explicitly
+const DefaultFile = "quiz-maker.yaml"
+
+// EnvPrefix is the prefix of every environment variable read by Load
+const EnvPrefix = "QUIZ_MAKER_"
+
+// Config holds the settings of both the server and the CLI.
+// Values are resolved in order of defaults, config file, environment variables and command line flags.
+type Config struct {
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Expiry   ExpiryConfig   `yaml:"expiry"`
+	Client   ClientConfig   `yaml:"client"`
+}
+
+type ServerConfig struct {
+	// Address is the host:port serve binds to
+	Address string `yaml:"address"`
+	// BaseURL is the public URL of the server, used for links such as the swagger definition.
+	// It is derived from Address when left empty.
+	BaseURL string `yaml:"baseUrl"`
+
+	// ReadTimeout, ReadHeaderTimeout, WriteTimeout and IdleTimeout are applied to the http.Server
+	ReadTimeout       time.Duration `yaml:"readTimeout"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout"`
+	// ShutdownTimeout is how long in-flight requests are given to finish after SIGINT or SIGTERM
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+}
+
+type DatabaseConfig struct {
+	Driver string `yaml:"driver"`
+	DSN    string `yaml:"dsn"`
+}
+
+type AuthConfig struct {
+	// Secret signs session tokens. When empty a random secret is generated on every start,
+	// which logs everyone out on restart and cannot be shared between instances.
+	Secret string `yaml:"secret"`
+	// TokenTTL is how long a session token stays valid after login
+	TokenTTL time.Duration `yaml:"tokenTtl"`
+}
+
+// ExpiryConfig controls how serve closes progressions that were never submitted
+type ExpiryConfig struct {
+	// Interval is how often serve looks for stale progressions, 0 turns expiry off
+	Interval time.Duration `yaml:"interval"`
+	// Inactivity is how long a progression can go without an answer before it expires,
+	// 0 only closes progressions that ran past their deadline
+	Inactivity time.Duration `yaml:"inactivity"`
+}
+
+type ClientConfig struct {
+	// Server is the base URL of the server the CLI commands talk to
+	Server string `yaml:"server"`
+	// Token is the session token sent by the CLI commands, the one saved by quiz-maker login is used when empty
+	Token string `yaml:"token"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Address:           ":8080",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			ShutdownTimeout:   20 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver: "sqlite",
+		},
+		Auth: AuthConfig{
+			TokenTTL: 24 * time.Hour,
+		},
+		Expiry: ExpiryConfig{
+			Interval:   time.Minute,
+			Inactivity: 24 * time.Hour,
+		},
+		Client: ClientConfig{
+			Server: "http://localhost:8080",
+		},
+	}
+}
+
+// Load reads the config file at path over the defaults and applies environment variables on top.
+// When path is empty DefaultFile is used if it exists.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	explicit := path != ""
+	if !explicit {
+		path = DefaultFile
+	}
+	b, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err = yaml.Unmarshal(b, cfg); err != nil {
+			return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+		}
+	case errors.Is(err, fs.ErrNotExist) && !explicit:
+	default:
+		return nil, fmt.Errorf("config: reading %s: %w", path, err)
+	}
+
+	if err = cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) applyEnv() error {
+	setFromEnv(&c.Server.Address, "SERVER_ADDRESS")
+	setFromEnv(&c.Server.BaseURL, "SERVER_BASE_URL")
+	setFromEnv(&c.Database.Driver, "DATABASE_DRIVER")
+	setFromEnv(&c.Database.DSN, "DATABASE_DSN")
+	setFromEnv(&c.Auth.Secret, "AUTH_SECRET")
+	setFromEnv(&c.Client.Server, "SERVER")
+	setFromEnv(&c.Client.Token, "TOKEN")
+
+	return errors.Join(
+		setDurationFromEnv(&c.Server.ReadTimeout, "SERVER_READ_TIMEOUT"),
+		setDurationFromEnv(&c.Server.ReadHeaderTimeout, "SERVER_READ_HEADER_TIMEOUT"),
+		setDurationFromEnv(&c.Server.WriteTimeout, "SERVER_WRITE_TIMEOUT"),
+		setDurationFromEnv(&c.Server.IdleTimeout, "SERVER_IDLE_TIMEOUT"),
+		setDurationFromEnv(&c.Server.ShutdownTimeout, "SERVER_SHUTDOWN_TIMEOUT"),
+		setDurationFromEnv(&c.Auth.TokenTTL, "AUTH_TOKEN_TTL"),
+		setDurationFromEnv(&c.Expiry.Interval, "EXPIRY_INTERVAL"),
+		setDurationFromEnv(&c.Expiry.Inactivity, "EXPIRY_INACTIVITY"),
+	)
+}
+
+func setFromEnv(target *string, name string) {
+	if v, ok := os.LookupEnv(EnvPrefix + name); ok {
+		*target = v
+	}
+}
+
+func setDurationFromEnv(target *time.Duration, name string) error {
+	v, ok := os.LookupEnv(EnvPrefix + name)
+	if !ok {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("config: %s%s: %w", EnvPrefix, name, err)
+	}
+	*target = d
+	return nil
+}
+
+// PublicURL returns BaseURL, or a localhost URL built from Address when BaseURL is not set
+func (s ServerConfig) PublicURL() string {
+	if s.BaseURL != "" {
+		return strings.TrimSuffix(s.BaseURL, "/")
+	}
+	host, port, err := net.SplitHostPort(s.Address)
+	if err != nil {
+		return "http://" + s.Address
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
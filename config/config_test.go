@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	// tests run in the package directory, which has no config file
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("loading without a config file: %v", err)
+	}
+	if *cfg != *Default() {
+		t.Fatalf("got %+v without a config file, want the defaults", cfg)
+	}
+	if _, err = Load(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Fatal("loading a missing config file that was asked for explicitly succeeded")
+	}
+
+	path := filepath.Join(dir, DefaultFile)
+	if err = os.WriteFile(path, []byte("server:\n  address: \":9000\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvPrefix+"DATABASE_DRIVER", "mysql")
+	if cfg, err = Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Address != ":9000" || cfg.Database.Driver != "mysql" || cfg.Client.Server != Default().Client.Server {
+		t.Fatalf("got %+v, want the address from the file, the driver from the env and the rest defaulted", cfg)
+	}
+
+	broken := filepath.Join(dir, "broken.yaml")
+	if err = os.WriteFile(broken, []byte("server: ["), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Load(broken); err == nil {
+		t.Fatal("loading a malformed config file succeeded")
+	}
+}
+
+func TestPublicURL(t *testing.T) {
+	tests := []struct {
+		server ServerConfig
+		want   string
+	}{
+		{ServerConfig{Address: ":8080"}, "http://localhost:8080"},
+		{ServerConfig{Address: "0.0.0.0:80"}, "http://localhost:80"},
+		{ServerConfig{Address: "quiz.local:8080"}, "http://quiz.local:8080"},
+		{ServerConfig{Address: ":8080", BaseURL: "https://quiz.example/"}, "https://quiz.example"},
+	}
+	for _, tt := range tests {
+		if got := tt.server.PublicURL(); got != tt.want {
+			t.Errorf("%+v: got %q, want %q", tt.server, got, tt.want)
+		}
+	}
+}
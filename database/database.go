@@ -0,0 +1,61 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+// DefaultDSN is used when no DSN is given for the sqlite driver so data survives restarts
+const DefaultDSN = "quiz-maker.db"
+
+// Open connects to the store selected by driver using the given DSN
+func Open(driver string, dsn string) (*gorm.DB, error) {
+	dialector, err := dialectorFor(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// TranslateError lets the store tell unique constraint violations apart from other failures
+	return gorm.Open(dialector, &gorm.Config{TranslateError: true})
+}
+
+func dialectorFor(driver string, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case DriverSQLite, "":
+		if dsn == "" {
+			dsn = DefaultDSN
+		}
+		return sqlite.Open(dsn), nil
+	case DriverPostgres:
+		if dsn == "" {
+			return nil, fmt.Errorf("database: dsn is required for driver %q", driver)
+		}
+		return postgres.Open(dsn), nil
+	case DriverMySQL:
+		if dsn == "" {
+			return nil, fmt.Errorf("database: dsn is required for driver %q", driver)
+		}
+		return mysql.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("database: unsupported driver %q, expected one of %s, %s, %s", driver, DriverSQLite, DriverPostgres, DriverMySQL)
+	}
+}
+
+// Close releases the underlying connection pool
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
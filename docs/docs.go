@@ -20,9 +20,173 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/login": {
+            "post": {
+                "description": "Checks the name and password of a user and issues a session token. The token is sent back in the Authorization header as \"Bearer \u003ctoken\u003e\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "User credentials",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Name or password is wrong",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Reports that the server process is up.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/quizzes": {
+            "get": {
+                "description": "Retrieves a paginated list of quizzes without their questions based on optional filters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Get a list of quizzes",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "List of quiz IDs",
+                        "name": "idList",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name to search for",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list quizzes with this status: draft, published or archived",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by: id, name or createdAt, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starts at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and 100 at most",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Creates a new quiz along with its questions and answers.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new quiz along with its questions and answers. Only authors and admins can create quizzes, the caller becomes the author.",
                 "consumes": [
                     "application/json"
                 ],
@@ -51,22 +215,51 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Quiz"
                         }
                     },
-                    "404": {
-                        "description": "Question not found",
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only authors and admins can create quizzes",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicting quiz",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             },
             "patch": {
-                "description": "Updates the details of an existing quiz.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the details of an existing quiz. Only the author of the quiz and admins can update it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -95,16 +288,40 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Quiz"
                         }
                     },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Question not found",
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
@@ -112,7 +329,12 @@ const docTemplate = `{
         },
         "/quizzes/answer": {
             "post": {
-                "description": "Submits an answer for the current question and updates the progression.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submits an answer for the current question and updates the progression. Only the user who began the progression can answer it.\nSingle questions are answered with optionId, multiple questions with the set of optionIds, text questions with text and numeric questions with number.\nOrdering questions are answered with every option id in order and matching questions with pairs of option ids and the items they match.\nThe response carries the next question in the order of the progression until every question is answered.\nAn answer after the current question ran out of time is not saved and the progression moves on to the next question,\nan answer after the quiz ran out of time is not saved and the progression is submitted with the answers given until then.\nBoth set timedOut, the latter also carries the score.",
                 "consumes": [
                     "application/json"
                 ],
@@ -142,21 +364,45 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Progression belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Question not found",
+                        "description": "Progression or question not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Quiz is already finished",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields or the answer does not fit the current question",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
@@ -164,7 +410,12 @@ const docTemplate = `{
         },
         "/quizzes/begin": {
             "post": {
-                "description": "Starts a quiz session for a user, initializing the progression with the first question.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts a quiz session for the authenticated user, initializing the progression with the first question.\nQuizzes with shuffling turned on give every progression its own seeded order of questions and options.\nOnly published quizzes can be begun, the progression takes the version saved when the quiz was last published.\nWhen the quiz or its first question has a time limit the response tells how many seconds are left.\nThe progression carries the number of the attempt, quizzes can limit the attempts of a user and the time between beginning two of them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -193,68 +444,112 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BeginQuizResponse"
                         }
                     },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Question not found",
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Quiz is not published or does not have any questions, no attempts are left or the cooldown has not passed",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/quizzes/questions/{id}": {
-            "get": {
-                "description": "Retrieves a question by its ID along with its answer options.",
-                "consumes": [
-                    "application/json"
+        "/quizzes/options/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Deletes an option of a question. Only the author of the quiz and admins can delete it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Quizzes"
                 ],
-                "summary": "Get a quiz question by ID",
+                "summary": "Delete an option",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Question ID",
+                        "description": "Option ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Malformed option id",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
                         "schema": {
-                            "$ref": "#/definitions/models.QuestionWithOptionsResponse"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Question not found",
+                        "description": "Option not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/quizzes/questions/{id}/options": {
-            "post": {
-                "description": "Creates a new option for a specific question by its ID.",
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the value, correctness, step or pair of an option. Only the author of the quiz and admins can update it.\nOnly options of single and multiple questions can be marked correct.",
                 "consumes": [
                     "application/json"
                 ],
@@ -262,58 +557,76 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Quiz"
+                    "Quizzes"
                 ],
-                "summary": "Create a question option",
+                "summary": "Update an option",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Question ID",
+                        "description": "Option ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Option creation payload",
-                        "name": "request",
+                        "description": "Updated option details",
+                        "name": "option",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateOptionRequest"
+                            "$ref": "#/definitions/models.UpdateOptionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created option",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.OptionBase"
+                            "$ref": "#/definitions/models.Option"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Malformed option id or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Question not found",
+                        "description": "Option not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/quizzes/submit": {
-            "post": {
-                "description": "Marks a quiz as finished and calculates the score based on correct answers.",
+        "/quizzes/questions/{id}": {
+            "get": {
+                "description": "Retrieves a question by its ID along with its answer options. Takers get a models.QuestionView without the answer key,\nthe author of the quiz and admins get the full models.Question including which options are correct.\nGiven a progression of the caller, the options are ordered the way that progression sees them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -323,85 +636,133 @@ const docTemplate = `{
                 "tags": [
                     "Quizzes"
                 ],
-                "summary": "Finalize a quiz",
+                "summary": "Get a quiz question by ID",
                 "parameters": [
                     {
-                        "description": "Quiz finalization details",
-                        "name": "finalizeQuiz",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.FinalizeQuizRequest"
-                        }
-                    }
-                ],
-                "responses": {
+                        "type": "string",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Progression to order the options for",
+                        "name": "progressionId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.FinalizeQuizResponse"
+                            "$ref": "#/definitions/models.QuestionView"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed question id or query",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Progression given without a valid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Progression belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Question not found",
+                        "description": "Question or progression not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Question does not belong to the quiz of the progression",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/quizzes/{id}": {
-            "get": {
-                "description": "Retrieves a quiz by its ID, including its questions and answers.",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Deletes a question along with its options. Only the author of the quiz and admins can delete it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Quizzes"
                 ],
-                "summary": "Get a quiz by ID",
+                "summary": "Delete a question",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Quiz ID",
+                        "description": "Question ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Malformed question id",
                         "schema": {
-                            "$ref": "#/definitions/models.Quiz"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Question not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes a quiz by its ID, including its associated questions and answers.",
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the text, type, scoring, points or answer key of a question. Only the author of the quiz and admins can update it.\nThe type of a question can only change while it has no options.\nThe change goes into the next version of the quiz, progressions and scores keep the version they were taken on.",
                 "consumes": [
                     "application/json"
                 ],
@@ -411,38 +772,79 @@ const docTemplate = `{
                 "tags": [
                     "Quizzes"
                 ],
-                "summary": "Delete a quiz",
+                "summary": "Update a question",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Quiz ID",
+                        "description": "Question ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated question details",
+                        "name": "question",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateQuestionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Question"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed question id or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
                     "404": {
                         "description": "Question not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/users": {
-            "get": {
-                "description": "Retrieves a paginated list of users based on optional filters.",
+        "/quizzes/questions/{id}/options": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new option for a specific question by its ID. Only the author of the quiz and admins can add options.",
                 "consumes": [
                     "application/json"
                 ],
@@ -450,67 +852,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Quiz"
                 ],
-                "summary": "Get a list of users",
+                "summary": "Create a question option",
                 "parameters": [
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "List of user IDs",
-                        "name": "id_list",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Name to search for",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "size",
-                        "in": "query",
-                        "required": true
+                        "description": "Option creation payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateOptionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created option",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "$ref": "#/definitions/models.OptionBase"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed question id or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Question not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields, a text or numeric question, or a correct option on a question other than single or multiple",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new user in the system.",
+            }
+        },
+        "/quizzes/questions/{id}/options/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Arranges the options of a question in the order of the given ids, which must list every option of the question once. Only the author of the quiz and admins can reorder them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -518,43 +934,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Quizzes"
                 ],
-                "summary": "Create a new user",
+                "summary": "Reorder the options of a question",
                 "parameters": [
                     {
-                        "description": "User details",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Option ids in their new order",
+                        "name": "order",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateUserRequest"
+                            "$ref": "#/definitions/models.ReorderRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Question"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed question id or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Question not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ids are not the options of the question",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Updates user details based on the provided request body.",
+            }
+        },
+        "/quizzes/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks a quiz as finished and calculates the score based on correct answers. Only the user who began the progression can submit it.\nEvery question earns its points times the credit of its answer, minus the penalties of the quiz for wrong and unanswered questions.\nThe score carries the raw and maximum points, the percentage and whether it reached the pass threshold of the quiz.",
                 "consumes": [
                     "application/json"
                 ],
@@ -562,17 +1016,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Quizzes"
                 ],
-                "summary": "Update an existing user",
+                "summary": "Finalize a quiz",
                 "parameters": [
                     {
-                        "description": "Updated user details",
-                        "name": "user",
+                        "description": "Quiz finalization details",
+                        "name": "finalizeQuiz",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateUserRequest"
+                            "$ref": "#/definitions/models.FinalizeQuizRequest"
                         }
                     }
                 ],
@@ -580,41 +1034,68 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.FinalizeQuizResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Progression belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Question not found",
+                        "description": "Progression or quiz not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Quiz does not have any questions or progression is already submitted",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
+        "/quizzes/versions/{id}": {
             "get": {
-                "description": "Retrieves a user by their ID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists every version of a quiz from the oldest to the newest without their snapshots.\nA version is saved whenever the quiz is published after it has been edited, progressions and scores keep the version they were taken on.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Quizzes"
                 ],
-                "summary": "Get a user by ID",
+                "summary": "List the versions of a quiz",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Quiz ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -624,58 +1105,865 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.QuizVersion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed quiz id",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Question not found",
+                        "description": "Quiz not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a user by their ID.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/quizzes/versions/{id}/{number}": {
+            "get": {
+                "description": "Retrieves a version of a quiz by its number with the questions and options it had. Takers get the snapshot as a models.QuizView without the answer key,\nthe author of the quiz and admins get the full models.Quiz including which options are correct.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Quizzes"
                 ],
-                "summary": "Delete a user",
+                "summary": "Get a version of a quiz",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Quiz ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuizVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed quiz id or version number",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz or version not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{id}": {
+            "get": {
+                "description": "Retrieves a quiz by its ID, including its questions and options. Takers get a models.QuizView without the answer key,\nthe author of the quiz and admins get the full models.Quiz including which options are correct.\nTakers see the quiz as it was last published, the author and admins see it with the edits made since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Get a quiz by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuizView"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed quiz id",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a quiz by its ID together with its questions, options and versions and every progression, answer and score taken on it. Only the author of the quiz and admins can delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Delete a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Malformed quiz id",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops a quiz from being begun. Attempts already begun can still be finished and every score is kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Archive a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed quiz id",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Makes a quiz available to takers after checking that every question can be answered and scored:\nsingle and multiple questions need a correct option, ordering and matching questions at least 2 options and text and numeric questions their answer key.\nThe quiz is saved as a new version unless it has not changed since it was last published. Archived quizzes can be published again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Publish a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed quiz id",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Quiz does not have any questions",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Questions that cannot be answered or scored, listed in details",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{id}/questions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Appends a question with its optional options to a quiz. Only the author of the quiz and admins can add questions.\nText questions carry their acceptedAnswers and numeric questions their numericAnswer instead of options.\nOptions of ordering questions are given in their correct order and options of matching questions with the pair they match.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Add a question to a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Question details",
+                        "name": "question",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Question"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed quiz id or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{id}/questions/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Arranges the questions of a quiz in the order of the given ids, which must list every question of the quiz once. Only the author of the quiz and admins can reorder it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Reorder the questions of a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Question ids in their new order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReorderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed quiz id or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the author of the quiz and admins can change it",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ids are not the questions of the quiz",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Reports whether the server accepts traffic. It fails while shutting down or when the database cannot be reached.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Server is not ready",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Retrieves a paginated list of users based on optional filters. Pages are selected either by page number or by the nextCursor of a previous page, which stays fast on large user tables.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get a list of users",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "List of user IDs",
+                        "name": "idList",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name to search for",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role to filter by: admin, author or taker",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starts at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and 100 at most",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of a previous page, used instead of page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid query parameters or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a new user with a password. Users always register as takers, operators make the first admin with the admin command.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User details",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User name is already taken",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the name or password of a user. Users can only update themselves, admins can update everyone and change roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update an existing user",
+                "parameters": [
+                    {
+                        "description": "Updated user details",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Users can only change their own account and only admins can change roles",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a user by their ID along with their answers. Users can only read themselves, admins can read everyone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get a user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed user id",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Users can only access their own account",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a user by their ID. Users can only delete themselves, admins can delete everyone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Malformed user id",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Users can only access their own account",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Question not found",
+                        "description": "User not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
@@ -683,7 +1971,12 @@ const docTemplate = `{
         },
         "/users/{userId}/quiz/{quizId}": {
             "get": {
-                "description": "Retrieves the user's score for a specific quiz.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the user's score for a specific quiz, optionally on a single version of it. Only the user, the author of the quiz and admins can see it.\nWhen the user took the quiz more than once, the score policy of the quiz decides whether the best, latest, first or average score counts.",
                 "tags": [
                     "Users"
                 ],
@@ -702,6 +1995,18 @@ const docTemplate = `{
                         "name": "quizId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the version of the quiz",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the attempt, the score the score policy of the quiz counts by default",
+                        "name": "attempt",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -711,10 +2016,114 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Score"
                         }
                     },
+                    "400": {
+                        "description": "Malformed user or quiz id or query",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Takers can only see their own results",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz, score, version or attempt not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/quiz/{quizId}/analysis": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the user's score along with the options they chose and the credit every question earned. Only the user, the author of the quiz and admins can see it.\nTakers get a models.ScoreAnalysisView without the answer key, the author of the quiz and admins get the full models.ReadUserScoreAnalysis including the correct options.\nThe quiz is shown as in the version the score was calculated on, a version can be given to analyse the score of that version.",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get analysis of user's score in a specific quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "quizId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the version of the quiz",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the attempt to analyse, the one the score policy of the quiz counts by default",
+                        "name": "attempt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScoreAnalysisView"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed user or quiz id or query",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Takers can only see their own results",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User, quiz, version, score or attempt not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
@@ -722,7 +2131,12 @@ const docTemplate = `{
         },
         "/users/{userId}/quiz/{quizId}/ranking": {
             "get": {
-                "description": "Retrieves the user's ranking, score, and percentage of quizzers they outperformed in a specific quiz.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the user's ranking, score, and percentage of quizzers they outperformed in a specific quiz. Only the user, the author of the quiz and admins can see it.\nGiven a version, only the scores of that version of the quiz are ranked. Every user is ranked by the score the score policy of the quiz counts.",
                 "tags": [
                     "Users"
                 ],
@@ -741,6 +2155,12 @@ const docTemplate = `{
                         "name": "quizId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the version of the quiz",
+                        "name": "version",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -750,10 +2170,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ReadUserRankingByScoreResponse"
                         }
                     },
+                    "400": {
+                        "description": "Malformed user or quiz id or query",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Takers can only see their own results",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz or version not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
@@ -770,12 +2214,27 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "number": {
+                    "type": "number"
+                },
                 "optionId": {
                     "type": "integer"
                 },
+                "position": {
+                    "type": "integer"
+                },
+                "progressionId": {
+                    "type": "integer"
+                },
+                "questionId": {
+                    "type": "integer"
+                },
                 "quizId": {
                     "type": "integer"
                 },
+                "text": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 },
@@ -784,60 +2243,137 @@ const docTemplate = `{
                 }
             }
         },
-        "models.AnswerQuizQuestionRequest": {
+        "models.AnswerPair": {
             "type": "object",
             "required": [
                 "optionId",
+                "pair"
+            ],
+            "properties": {
+                "optionId": {
+                    "type": "integer"
+                },
+                "pair": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.AnswerQuizQuestionRequest": {
+            "type": "object",
+            "required": [
                 "progressionId"
             ],
             "properties": {
+                "number": {
+                    "type": "number"
+                },
                 "optionId": {
                     "type": "integer"
                 },
+                "optionIds": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "order": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "pairs": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/models.AnswerPair"
+                    }
+                },
                 "progressionId": {
                     "type": "integer"
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 1000
                 }
             }
         },
         "models.AnswerQuizQuestionResponse": {
             "type": "object",
             "properties": {
+                "currentQuestion": {
+                    "$ref": "#/definitions/models.QuestionView"
+                },
                 "progression": {
                     "$ref": "#/definitions/models.Progression"
+                },
+                "questionRemainingSeconds": {
+                    "type": "integer"
+                },
+                "remainingSeconds": {
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "Score is the score of the progression when it was submitted because the quiz ran out of time",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Score"
+                        }
+                    ]
+                },
+                "timedOut": {
+                    "description": "TimedOut tells that the answer came after the question or the quiz ran out of time and was not saved",
+                    "type": "boolean"
                 }
             }
         },
         "models.BeginQuizRequest": {
             "type": "object",
             "required": [
-                "quizId",
-                "userId"
+                "quizId"
             ],
             "properties": {
                 "quizId": {
                     "type": "integer"
-                },
-                "userId": {
-                    "type": "integer"
                 }
             }
         },
         "models.BeginQuizResponse": {
             "type": "object",
             "properties": {
+                "currentQuestion": {
+                    "$ref": "#/definitions/models.QuestionView"
+                },
                 "progression": {
                     "$ref": "#/definitions/models.Progression"
+                },
+                "questionRemainingSeconds": {
+                    "type": "integer"
+                },
+                "remainingSeconds": {
+                    "type": "integer"
                 }
             }
         },
         "models.CreateOptionRequest": {
             "type": "object",
+            "required": [
+                "value"
+            ],
             "properties": {
                 "isCorrect": {
                     "type": "boolean"
                 },
+                "pair": {
+                    "type": "string",
+                    "maxLength": 255
+                },
                 "value": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
@@ -847,13 +2383,60 @@ const docTemplate = `{
                 "question"
             ],
             "properties": {
+                "acceptedAnswers": {
+                    "description": "AcceptedAnswers, Matching and CaseSensitive only apply to text questions, Matching is exact by default",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "caseSensitive": {
+                    "type": "boolean"
+                },
+                "matching": {
+                    "type": "string"
+                },
+                "numericAnswer": {
+                    "description": "NumericAnswer and Tolerance only apply to numeric questions",
+                    "type": "number"
+                },
                 "options": {
                     "type": "array",
+                    "maxItems": 50,
                     "items": {
                         "$ref": "#/definitions/models.CreateOptionRequest"
                     }
                 },
+                "penalty": {
+                    "description": "Penalty only applies to partial scoring, 1 by default so picking every option earns nothing",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "points": {
+                    "description": "Points is what the question is worth, 1 by default. TimeLimit is in seconds, 0 means no limit",
+                    "type": "number",
+                    "maximum": 1000
+                },
                 "question": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "scoring": {
+                    "description": "Scoring is one of ScoringRules, all_or_nothing by default",
+                    "type": "string"
+                },
+                "timeLimit": {
+                    "type": "integer",
+                    "maximum": 86400
+                },
+                "tolerance": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "type": {
+                    "description": "Type is one of QuestionTypes, single by default",
                     "type": "string"
                 }
             }
@@ -861,27 +2444,88 @@ const docTemplate = `{
         "models.CreateQuizRequest": {
             "type": "object",
             "required": [
-                "name"
+                "name",
+                "questions"
             ],
             "properties": {
+                "cooldown": {
+                    "type": "integer",
+                    "maximum": 31536000
+                },
+                "maxAttempts": {
+                    "description": "MaxAttempts of 0 means no limit, Cooldown is in seconds and ScorePolicy one of ScorePolicies, best by default",
+                    "type": "integer",
+                    "maximum": 1000
+                },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "passThreshold": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
                 },
                 "questions": {
                     "type": "array",
+                    "maxItems": 200,
                     "items": {
                         "$ref": "#/definitions/models.CreateQuestionRequest"
                     }
+                },
+                "scorePolicy": {
+                    "type": "string"
+                },
+                "shuffleOptions": {
+                    "type": "boolean"
+                },
+                "shuffleQuestions": {
+                    "type": "boolean"
+                },
+                "timeLimit": {
+                    "type": "integer",
+                    "maximum": 86400
+                },
+                "unansweredPenalty": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "wrongPenalty": {
+                    "description": "WrongPenalty and UnansweredPenalty are shares of the points of a question, PassThreshold a percentage\nand TimeLimit is in seconds with 0 meaning no limit",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
                 }
             }
         },
         "models.CreateUserRequest": {
             "type": "object",
             "required": [
-                "name"
+                "name",
+                "password"
             ],
             "properties": {
                 "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "password": {
+                    "description": "Password is limited to 72 bytes by bcrypt",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {},
+                "message": {
                     "type": "string"
                 }
             }
@@ -905,123 +2549,463 @@ const docTemplate = `{
                 }
             }
         },
+        "models.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "password"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Token is sent back as \"Authorization: Bearer \u003ctoken\u003e\" until it expires",
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
         "models.Option": {
             "type": "object",
             "properties": {
                 "answers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Answer"
+                        "$ref": "#/definitions/models.Answer"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isCorrect": {
+                    "type": "boolean"
+                },
+                "pair": {
+                    "description": "Pair is the item the option has to be matched with in a matching question",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position orders the options of a question, options are shown from the lowest position up",
+                    "type": "integer"
+                },
+                "questionId": {
+                    "type": "integer"
+                },
+                "step": {
+                    "description": "Step is the place of the option in the correct order of an ordering question",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OptionBase": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Answer"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "Position orders the options of a question, options are shown from the lowest position up",
+                    "type": "integer"
+                },
+                "questionId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OptionView": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "questionId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PaginationResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "array",
+                    "items": {}
+                },
+                "next": {
+                    "description": "Next and Prev link to the neighbouring pages, they are left out on the first and last pages",
+                    "type": "string"
+                },
+                "nextCursor": {
+                    "description": "NextCursor continues the listing after the last item of this page when set",
+                    "type": "string"
+                },
+                "page": {
+                    "description": "Page is 0 when the page was requested with a cursor",
+                    "type": "integer"
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Progression": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "description": "Attempt counts the attempts of the user on the quiz from 1 up, attempts that expired count as well.\nA user cannot begin the same attempt twice, so concurrent begins cannot take more attempts than the quiz allows.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currentQuestionId": {
+                    "type": "integer"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isFinished": {
+                    "type": "boolean"
+                },
+                "optionOrder": {
+                    "description": "OptionOrder holds the option ids of every question in the order this attempt sees them when options are shuffled",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "outcome": {
+                    "description": "Outcome is one of ProgressionOutcomes once the attempt is closed, it is empty while the attempt is open",
+                    "type": "string"
+                },
+                "questionDeadline": {
+                    "description": "QuestionDeadline is when the current question times out when it has a time limit, it never lies past Deadline",
+                    "type": "string"
+                },
+                "questionNumber": {
+                    "type": "integer"
+                },
+                "questionOrder": {
+                    "description": "QuestionOrder holds the question ids in the order this attempt gets them",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "quizId": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "description": "Seed is the random seed the question and option orders of this attempt were shuffled with",
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "description": "StartedAt is when the attempt began, Deadline when it is submitted on its own when the quiz has a time limit",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "versionId": {
+                    "description": "VersionID is the version of the quiz this attempt takes, it is 0 for attempts from before quizzes had versions",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Question": {
+            "type": "object",
+            "properties": {
+                "acceptedAnswers": {
+                    "description": "AcceptedAnswers are the answers a text question accepts, compared as Matching says",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "caseSensitive": {
+                    "description": "CaseSensitive makes either matching tell upper and lower case apart",
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "matching": {
+                    "description": "Matching is one of TextMatchings",
+                    "type": "string"
+                },
+                "numericAnswer": {
+                    "description": "NumericAnswer is the number a numeric question expects, answers within Tolerance of it are accepted",
+                    "type": "number"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Option"
                     }
                 },
-                "createdAt": {
+                "penalty": {
+                    "description": "Penalty is the share of the credit lost under partial scoring when every wrong option is picked,\neach wrong pick costs an equal part of it",
+                    "type": "number"
+                },
+                "points": {
+                    "description": "Points is what the question is worth when it earns full credit",
+                    "type": "number"
+                },
+                "position": {
+                    "description": "Position orders the questions of a quiz, takers get them from the lowest position up",
+                    "type": "integer"
+                },
+                "question": {
                     "type": "string"
                 },
-                "id": {
+                "quizId": {
                     "type": "integer"
                 },
-                "isCorrect": {
-                    "type": "boolean"
+                "scoring": {
+                    "description": "Scoring is one of ScoringRules and decides how much credit an answer to a multiple, ordering or matching question earns",
+                    "type": "string"
                 },
-                "questionId": {
+                "timeLimit": {
+                    "description": "TimeLimit is how many seconds the question can be answered in once it is shown, 0 means no limit",
                     "type": "integer"
                 },
-                "updatedAt": {
+                "tolerance": {
+                    "type": "number"
+                },
+                "type": {
+                    "description": "Type is one of QuestionTypes and decides how the question is answered",
                     "type": "string"
                 },
-                "value": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "models.OptionBase": {
+        "models.QuestionResult": {
             "type": "object",
             "properties": {
-                "answers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Answer"
-                    }
+                "answered": {
+                    "type": "boolean"
                 },
-                "createdAt": {
-                    "type": "string"
+                "credit": {
+                    "type": "number"
                 },
-                "id": {
-                    "type": "integer"
+                "maxPoints": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "number"
                 },
                 "questionId": {
                     "type": "integer"
-                },
-                "updatedAt": {
-                    "type": "string"
-                },
-                "value": {
-                    "type": "string"
                 }
             }
         },
-        "models.Progression": {
+        "models.QuestionView": {
             "type": "object",
             "properties": {
                 "createdAt": {
                     "type": "string"
                 },
-                "currentQuestionId": {
-                    "type": "integer"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "isFinished": {
-                    "type": "boolean"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OptionView"
+                    }
                 },
-                "questionNumber": {
+                "pairs": {
+                    "description": "Pairs are the items the options of a matching question are paired with, sorted so they do not give away which option they belong to",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "penalty": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "position": {
                     "type": "integer"
                 },
+                "question": {
+                    "type": "string"
+                },
                 "quizId": {
                     "type": "integer"
                 },
-                "updatedAt": {
+                "scoring": {
                     "type": "string"
                 },
-                "userId": {
+                "timeLimit": {
+                    "description": "TimeLimit is how many seconds the question can be answered in, 0 means no limit",
                     "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "models.Question": {
+        "models.Quiz": {
             "type": "object",
             "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Answer"
+                    }
+                },
+                "authorId": {
+                    "description": "AuthorID is the user who created the quiz, only they and admins can change it",
+                    "type": "integer"
+                },
+                "cooldown": {
+                    "description": "Cooldown is how many seconds a user waits after an attempt finished before the next one can begin",
+                    "type": "integer"
+                },
                 "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Option"
-                    }
+                "maxAttempts": {
+                    "description": "MaxAttempts is how many times a user can begin the quiz, 0 means no limit",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "passThreshold": {
+                    "description": "PassThreshold is the percentage a score needs to pass, every score passes when it is 0",
+                    "type": "number"
                 },
-                "progressions": {
+                "questions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Progression"
+                        "$ref": "#/definitions/models.Question"
                     }
                 },
-                "question": {
+                "scorePolicy": {
+                    "description": "ScorePolicy is one of ScorePolicies and decides which score of a user counts when they took the quiz more than once",
                     "type": "string"
                 },
-                "quizId": {
+                "shuffleOptions": {
+                    "type": "boolean"
+                },
+                "shuffleQuestions": {
+                    "description": "ShuffleQuestions and ShuffleOptions give every attempt its own random order of questions and options",
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "Status is one of QuizStatuses, only published quizzes can be begun",
+                    "type": "string"
+                },
+                "timeLimit": {
+                    "description": "TimeLimit is how many seconds an attempt can take before it is submitted on its own, 0 means no limit",
                     "type": "integer"
                 },
+                "unansweredPenalty": {
+                    "description": "UnansweredPenalty is the share of its points a question costs when it is left unanswered, by default it just earns nothing",
+                    "type": "number"
+                },
                 "updatedAt": {
                     "type": "string"
+                },
+                "wrongPenalty": {
+                    "description": "WrongPenalty is the share of its points a question costs when it is answered without earning any credit",
+                    "type": "number"
                 }
             }
         },
-        "models.QuestionWithOptionsResponse": {
+        "models.QuizVersion": {
             "type": "object",
             "properties": {
                 "createdAt": {
@@ -1030,31 +3014,34 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.OptionBase"
-                    }
-                },
-                "question": {
-                    "type": "string"
+                "number": {
+                    "description": "Number counts the versions of a quiz from 1 up",
+                    "type": "integer"
                 },
                 "quizId": {
                     "type": "integer"
                 },
+                "snapshot": {
+                    "description": "Snapshot is left out when versions are listed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Quiz"
+                        }
+                    ]
+                },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "models.Quiz": {
+        "models.QuizView": {
             "type": "object",
             "properties": {
-                "answers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Answer"
-                    }
+                "authorId": {
+                    "type": "integer"
+                },
+                "cooldown": {
+                    "type": "integer"
                 },
                 "createdAt": {
                     "type": "string"
@@ -1062,29 +3049,51 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "maxAttempts": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
+                "passThreshold": {
+                    "type": "number"
+                },
                 "questions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Question"
+                        "$ref": "#/definitions/models.QuestionView"
                     }
                 },
+                "scorePolicy": {
+                    "type": "string"
+                },
+                "shuffleOptions": {
+                    "type": "boolean"
+                },
+                "shuffleQuestions": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timeLimit": {
+                    "type": "integer"
+                },
+                "unansweredPenalty": {
+                    "type": "number"
+                },
                 "updatedAt": {
                     "type": "string"
+                },
+                "wrongPenalty": {
+                    "description": "WrongPenalty, UnansweredPenalty and PassThreshold tell takers how they are scored",
+                    "type": "number"
                 }
             }
         },
         "models.ReadUserRankingByScoreResponse": {
             "type": "object",
             "properties": {
-                "givenAnswers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Option"
-                    }
-                },
                 "message": {
                     "type": "string"
                 },
@@ -1099,19 +3108,57 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ReorderRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
         "models.Score": {
             "type": "object",
             "properties": {
+                "attempt": {
+                    "description": "Attempt counts the attempts of the user on the quiz from 1 up, it is 0 for a score averaged over several attempts",
+                    "type": "integer"
+                },
                 "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "maxPoints": {
+                    "type": "number"
+                },
+                "passed": {
+                    "description": "Passed tells whether Percentage reached the pass threshold of the quiz",
+                    "type": "boolean"
+                },
+                "percentage": {
+                    "description": "Percentage is RawPoints out of MaxPoints, it never drops below 0",
+                    "type": "number"
+                },
+                "progressionId": {
+                    "description": "ProgressionID is the attempt the score was calculated from, it is 0 for scores from before progressions were kept",
+                    "type": "integer"
+                },
                 "quizId": {
                     "type": "integer"
                 },
+                "rawPoints": {
+                    "description": "RawPoints are the points earned after penalties, MaxPoints what every question is worth together",
+                    "type": "number"
+                },
                 "score": {
+                    "description": "Score is Percentage as a fraction between 0 and 1",
                     "type": "number"
                 },
                 "updatedAt": {
@@ -1119,6 +3166,114 @@ const docTemplate = `{
                 },
                 "userId": {
                     "type": "integer"
+                },
+                "versionId": {
+                    "description": "VersionID is the version of the quiz the score was calculated on, it is 0 for scores from before quizzes had versions",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ScoreAnalysisView": {
+            "type": "object",
+            "properties": {
+                "countedScore": {
+                    "$ref": "#/definitions/models.Score"
+                },
+                "progression": {
+                    "$ref": "#/definitions/models.Progression"
+                },
+                "quiz": {
+                    "$ref": "#/definitions/models.QuizView"
+                },
+                "results": {
+                    "description": "Results holds the credit the answers earned on every question of the quiz",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QuestionResult"
+                    }
+                },
+                "score": {
+                    "$ref": "#/definitions/models.Score"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "userAnswers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OptionView"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UpdateOptionRequest": {
+            "type": "object",
+            "properties": {
+                "isCorrect": {
+                    "type": "boolean"
+                },
+                "pair": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "step": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "value": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.UpdateQuestionRequest": {
+            "type": "object",
+            "properties": {
+                "acceptedAnswers": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "caseSensitive": {
+                    "type": "boolean"
+                },
+                "matching": {
+                    "type": "string"
+                },
+                "numericAnswer": {
+                    "type": "number"
+                },
+                "penalty": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "points": {
+                    "type": "number",
+                    "maximum": 1000
+                },
+                "question": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "scoring": {
+                    "type": "string"
+                },
+                "timeLimit": {
+                    "type": "integer",
+                    "maximum": 86400
+                },
+                "tolerance": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
@@ -1128,11 +3283,50 @@ const docTemplate = `{
                 "id"
             ],
             "properties": {
+                "cooldown": {
+                    "type": "integer",
+                    "maximum": 31536000
+                },
                 "id": {
                     "type": "integer"
                 },
+                "maxAttempts": {
+                    "description": "MaxAttempts, Cooldown and ScorePolicy apply right away, to attempts and scores from before as well",
+                    "type": "integer",
+                    "maximum": 1000
+                },
                 "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "passThreshold": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "scorePolicy": {
                     "type": "string"
+                },
+                "shuffleOptions": {
+                    "type": "boolean"
+                },
+                "shuffleQuestions": {
+                    "description": "ShuffleQuestions, ShuffleOptions, WrongPenalty, UnansweredPenalty, PassThreshold and TimeLimit\nonly apply to attempts on versions published afterwards",
+                    "type": "boolean"
+                },
+                "timeLimit": {
+                    "type": "integer",
+                    "maximum": 86400
+                },
+                "unansweredPenalty": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "wrongPenalty": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
                 }
             }
         },
@@ -1146,6 +3340,16 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "role": {
+                    "description": "Role can only be changed by admins",
                     "type": "string"
                 }
             }
@@ -1168,11 +3372,22 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "role": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Session token from POST /auth/login, sent as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -1,11 +1,13 @@
 #!/bin/bash
-./quiz-maker create user XY
+QUIZ_MAKER_ADMIN_PASSWORD=secret123 ./quiz-maker admin XY
+./quiz-maker login XY secret123
 ./quiz-maker create quiz test "is Test?,is not a Test?"
 ./quiz-maker create option 1 "Yes" true
 ./quiz-maker create option 1 "No" false
 ./quiz-maker create option 2 "Yes" false
 ./quiz-maker create option 2 "No" true
-./quiz-maker begin 1 1
+./quiz-maker publish 1
+./quiz-maker begin 1
 ./quiz-maker answer 1 1
 ./quiz-maker answer 1 4
 ./quiz-maker submit 1
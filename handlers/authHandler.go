@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/service"
+)
+
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+func newAuthHandler(s *service.AuthService) *AuthHandler {
+	return &AuthHandler{service: s}
+}
+
+func (h *AuthHandler) ConfigureSelf(m *http.ServeMux) *http.ServeMux {
+	m.HandleFunc("POST /auth/login", h.login)
+
+	return m
+}
+
+// login
+// @Summary Log in
+// @Description Checks the name and password of a user and issues a session token. The token is sent back in the Authorization header as "Bearer <token>".
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param login body models.LoginRequest true "User credentials"
+// @Success 200 {object} models.LoginResponse
+// @Failure      400     {object}  models.ErrorResponse  "Malformed request body"
+// @Failure      401     {object}  models.ErrorResponse  "Name or password is wrong"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Router /auth/login [post]
+func (h *AuthHandler) login(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => Login invoked", r.Method, r.URL.Path)
+	request, err := readJSON[models.LoginRequest](r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response, err := h.service.Login(r.Context(), request)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+type callerKey struct{}
+
+// caller is the outcome of resolving the bearer token of a request
+type caller struct {
+	user *models.User
+	err  error
+}
+
+// Authenticate resolves the user behind the bearer token of a request and makes it available to the handlers.
+// Requests without a valid token pass through anonymously, the token error is reported by the endpoints requiring a user
+// so a stale token does not get in the way of registering or logging in again.
+func Authenticate(s *service.AuthService, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var c caller
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			c.err = service.ErrInvalidToken.WithDetails("expected Authorization: Bearer <token>")
+		} else {
+			c.user, c.err = s.Authenticate(r.Context(), strings.TrimSpace(token))
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), callerKey{}, c)))
+	})
+}
+
+// callerOf returns the authenticated user of the request.
+// Anonymous requests get ErrUnauthenticated and requests with a bad token the reason it was rejected.
+func callerOf(r *http.Request) (*models.User, error) {
+	c, ok := r.Context().Value(callerKey{}).(caller)
+	if !ok {
+		return nil, service.ErrUnauthenticated
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.user, nil
+}
+
+// optionalCallerOf returns the authenticated user of the request, or nil when it is anonymous or its token was rejected.
+// It is used by endpoints that are public but show more to some users.
+func optionalCallerOf(r *http.Request) *models.User {
+	user, err := callerOf(r)
+	if err != nil {
+		return nil
+	}
+	return user
+}
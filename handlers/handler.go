@@ -1,18 +1,160 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
 
-	"gorm.io/gorm"
+	"github.com/gorilla/schema"
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/service"
+	"github.com/lghtr35/quiz-maker/util"
+	"github.com/lghtr35/quiz-maker/validation"
 )
 
 type Handler interface {
 	ConfigureSelf(m *http.ServeMux) *http.ServeMux
 }
 
-func InitializeHandlers(db *gorm.DB) []Handler {
+func InitializeHandlers(quizService *service.QuizService, userService *service.UserService, authService *service.AuthService) []Handler {
 	return []Handler{
-		newUserHandler(db),
-		newQuizHandler(db),
+		newAuthHandler(authService),
+		newUserHandler(userService),
+		newQuizHandler(quizService),
 	}
 }
+
+// writeJSON marshals v and writes it with the given status
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+// writeError writes err as an error envelope. Errors that are not service errors are logged
+// and reported as internal errors so database and other internal messages do not leak to clients.
+func writeError(w http.ResponseWriter, err error) {
+	var e *service.Error
+	if !errors.As(err, &e) {
+		log.Printf("Internal error: %v", err)
+		e = service.ErrInternal
+	}
+
+	b, err := json.Marshal(models.ErrorResponse{
+		Code:    e.Code,
+		Message: e.Message,
+		Details: e.Details,
+	})
+	if err != nil {
+		log.Printf("Could not marshal error response: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusOf(e.Kind))
+	w.Write(b)
+}
+
+func statusOf(kind service.Kind) int {
+	switch kind {
+	case service.KindInvalid:
+		return http.StatusBadRequest
+	case service.KindNotFound:
+		return http.StatusNotFound
+	case service.KindConflict:
+		return http.StatusConflict
+	case service.KindUnprocessable:
+		return http.StatusUnprocessableEntity
+	case service.KindUnavailable:
+		return http.StatusServiceUnavailable
+	case service.KindUnauthenticated:
+		return http.StatusUnauthorized
+	case service.KindForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// readJSON decodes and validates the request body into a T.
+// Malformed bodies are reported as invalid_body and invalid fields as validation_failed.
+func readJSON[T any](r *http.Request) (T, error) {
+	var val T
+	val, err := util.ReadBodyAndUnmarshal(val, r.Body)
+	if err != nil {
+		return val, service.ErrInvalidBody.WithDetails(err.Error())
+	}
+	if errs := validation.Struct(val); errs != nil {
+		return val, service.ErrValidation.WithDetails(errs)
+	}
+	return val, nil
+}
+
+// newQueryDecoder returns a decoder reading query parameters by the json names of request fields
+func newQueryDecoder() *schema.Decoder {
+	d := schema.NewDecoder()
+	d.SetAliasTag("json")
+	return d
+}
+
+// readQuery decodes and validates the query parameters into a T.
+// Malformed parameters are reported as invalid_query and invalid fields as validation_failed.
+func readQuery[T any](d *schema.Decoder, r *http.Request) (T, error) {
+	var val T
+	if err := d.Decode(&val, r.URL.Query()); err != nil {
+		return val, service.ErrInvalidQuery.WithDetails(err.Error())
+	}
+	if errs := validation.Struct(val); errs != nil {
+		return val, service.ErrValidation.WithDetails(errs)
+	}
+	return val, nil
+}
+
+// setPageLinks fills the next and prev links of a pagination envelope from the request URL.
+// Pages requested with a cursor only link forward with the next cursor.
+func setPageLinks(r *http.Request, p *models.PaginationResponse) {
+	link := func(set map[string]string) *string {
+		q := r.URL.Query()
+		q.Set("size", strconv.FormatUint(uint64(p.Size), 10))
+		for k, v := range set {
+			if v == "" {
+				q.Del(k)
+			} else {
+				q.Set(k, v)
+			}
+		}
+		l := r.URL.Path + "?" + q.Encode()
+		return &l
+	}
+
+	if p.Page == 0 {
+		if p.NextCursor != nil {
+			p.Next = link(map[string]string{"cursor": *p.NextCursor, "page": ""})
+		}
+		return
+	}
+	if p.Page < p.TotalPages {
+		p.Next = link(map[string]string{"page": strconv.FormatUint(uint64(p.Page+1), 10)})
+	}
+	if p.Page > 1 {
+		p.Prev = link(map[string]string{"page": strconv.FormatUint(uint64(p.Page-1), 10)})
+	}
+}
+
+// pathID parses the named path value as an entity id
+func pathID(r *http.Request, name string) (uint32, error) {
+	id, err := strconv.ParseUint(r.PathValue(name), 10, 32)
+	if err != nil || id == 0 {
+		return 0, service.ErrInvalidID.WithDetails(name)
+	}
+	return uint32(id), nil
+}
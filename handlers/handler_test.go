@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/service"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		details any
+	}{
+		{"invalid", service.ErrInvalidBody.WithDetails("unexpected EOF"), http.StatusBadRequest, "invalid_body", "unexpected EOF"},
+		{"not found", service.ErrQuizNotFound, http.StatusNotFound, "quiz_not_found", nil},
+		{"conflict", service.ErrQuizFinished, http.StatusConflict, "progression_finished", nil},
+		{"unprocessable", service.ErrOptionNotInQuestion, http.StatusUnprocessableEntity, "option_not_in_question", nil},
+		{"internal", service.ErrInternal, http.StatusInternalServerError, "internal_error", nil},
+		{"wrapped", errors.Join(errors.New("while answering"), service.ErrQuestionNotFound), http.StatusNotFound, "question_not_found", nil},
+		{"unknown", errors.New("sql: connection refused"), http.StatusInternalServerError, "internal_error", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			writeError(w, tt.err)
+
+			if w.Code != tt.status {
+				t.Fatalf("got status %d, want %d", w.Code, tt.status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Fatalf("got content type %q", ct)
+			}
+			var body models.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Code != tt.code || body.Details != tt.details {
+				t.Fatalf("got code %q with details %v, want %q with %v", body.Code, body.Details, tt.code, tt.details)
+			}
+			if body.Message == "" || body.Message == "sql: connection refused" {
+				t.Fatalf("got message %q", body.Message)
+			}
+		})
+	}
+}
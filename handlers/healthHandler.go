@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/service"
+)
+
+// Pinger reports whether a dependency of the server can be reached
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// HealthHandler serves the liveness and readiness probes of the server
+type HealthHandler struct {
+	pinger Pinger
+	ready  atomic.Bool
+}
+
+// NewHealthHandler returns a HealthHandler that reports ready until SetReady(false) is called
+func NewHealthHandler(p Pinger) *HealthHandler {
+	h := &HealthHandler{pinger: p}
+	h.ready.Store(true)
+	return h
+}
+
+func (h *HealthHandler) ConfigureSelf(m *http.ServeMux) *http.ServeMux {
+	m.HandleFunc("GET /healthz", h.live)
+	m.HandleFunc("GET /readyz", h.readiness)
+
+	return m
+}
+
+// SetReady changes what the readiness probe reports, it is turned off while the server drains requests
+func (h *HealthHandler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// live
+// @Summary Liveness probe
+// @Description Reports that the server process is up.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.HealthResponse
+// @Router /healthz [get]
+func (h *HealthHandler) live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, models.HealthResponse{Status: "ok"})
+}
+
+// readiness
+// @Summary Readiness probe
+// @Description Reports whether the server accepts traffic. It fails while shutting down or when the database cannot be reached.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.HealthResponse
+// @Failure      503     {object}  models.ErrorResponse  "Server is not ready"
+// @Router /readyz [get]
+func (h *HealthHandler) readiness(w http.ResponseWriter, r *http.Request) {
+	if !h.ready.Load() {
+		writeError(w, service.ErrNotReady.WithDetails("shutting down"))
+		return
+	}
+	if err := h.pinger.Ping(r.Context()); err != nil {
+		writeError(w, service.ErrNotReady.WithDetails("database unreachable"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.HealthResponse{Status: "ready"})
+}
@@ -1,33 +1,44 @@
 package handlers
 
 import (
-	"encoding/json"
-	"io"
 	"log"
 	"net/http"
+	"time"
 
+	"github.com/gorilla/schema"
 	"github.com/lghtr35/quiz-maker/models"
-	"github.com/lghtr35/quiz-maker/util"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
+	"github.com/lghtr35/quiz-maker/service"
 )
 
 type QuizHandler struct {
-	db *gorm.DB
+	service *service.QuizService
+	decoder *schema.Decoder
 }
 
-func newQuizHandler(db *gorm.DB) *QuizHandler {
-	return &QuizHandler{db: db}
+func newQuizHandler(s *service.QuizService) *QuizHandler {
+	return &QuizHandler{service: s, decoder: newQueryDecoder()}
 }
 
 func (h *QuizHandler) ConfigureSelf(m *http.ServeMux) *http.ServeMux {
 	m.HandleFunc("GET /quizzes/questions/{id}", h.getQuestion)
+	m.HandleFunc("POST /quizzes/{id}/questions", h.createQuestion)
+	m.HandleFunc("PATCH /quizzes/questions/{id}", h.updateQuestion)
+	m.HandleFunc("DELETE /quizzes/questions/{id}", h.deleteQuestion)
+	m.HandleFunc("PUT /quizzes/{id}/questions/order", h.reorderQuestions)
 	m.HandleFunc("POST /quizzes/questions/{id}/options", h.createQuestionOption)
+	m.HandleFunc("PUT /quizzes/questions/{id}/options/order", h.reorderOptions)
+	m.HandleFunc("PATCH /quizzes/options/{id}", h.updateOption)
+	m.HandleFunc("DELETE /quizzes/options/{id}", h.deleteOption)
+	m.HandleFunc("GET /quizzes/versions/{id}", h.readQuizVersions)
+	m.HandleFunc("GET /quizzes/versions/{id}/{number}", h.readQuizVersion)
 
+	m.HandleFunc("GET /quizzes", h.readQuizzes)
 	m.HandleFunc("GET /quizzes/{id}", h.readQuizWithID)
 	m.HandleFunc("POST /quizzes", h.createQuiz)
 	m.HandleFunc("PATCH /quizzes", h.updateQuiz)
 	m.HandleFunc("DELETE /quizzes/{id}", h.deleteQuiz)
+	m.HandleFunc("POST /quizzes/{id}/publish", h.publishQuiz)
+	m.HandleFunc("POST /quizzes/{id}/archive", h.archiveQuiz)
 
 	m.HandleFunc("POST /quizzes/begin", h.beginQuiz)
 	m.HandleFunc("POST /quizzes/answer", h.answerQuizQuestion)
@@ -38,625 +49,865 @@ func (h *QuizHandler) ConfigureSelf(m *http.ServeMux) *http.ServeMux {
 
 // createQuiz
 // @Summary Create a new quiz
-// @Description Creates a new quiz along with its questions and answers.
+// @Description Creates a new quiz along with its questions and answers. Only authors and admins can create quizzes, the caller becomes the author.
 // @Tags Quizzes
 // @Accept json
 // @Produce json
 // @Param quiz body models.CreateQuizRequest true "Quiz details"
 // @Success 201 {object} models.Quiz
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only authors and admins can create quizzes"
+// @Failure      409     {object}  models.ErrorResponse  "Conflicting quiz"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
 // @Router /quizzes [post]
 func (h *QuizHandler) createQuiz(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => CreateQuiz invoked", r.Method, r.URL.Path)
-	var request models.CreateQuizRequest
-
-	b, err := io.ReadAll(r.Body)
+	caller, err := callerOf(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-
-	if err = json.Unmarshal(b, &request); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	quiz := models.Quiz{
-		Name: request.Name,
-	}
-	res := h.db.Create(&quiz)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	request, err := readJSON[models.CreateQuizRequest](r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	for _, q := range request.Questions {
-		question := models.Question{
-			Question: q.Question,
-			QuizID:   quiz.ID,
-		}
-		res := h.db.Create(&question)
-		if res.Error != nil {
-			http.Error(w, res.Error.Error(), http.StatusInternalServerError)
-			return
-		}
-		if q.Options != nil {
-			for _, a := range *q.Options {
-				answer := models.Option{
-					OptionBase: models.OptionBase{
-						Value:      a.Value,
-						QuestionID: question.ID,
-					},
-					IsCorrect: a.IsCorrect,
-				}
-				res := h.db.Create(&answer)
-				if res.Error != nil {
-					http.Error(w, res.Error.Error(), http.StatusInternalServerError)
-					return
-				}
-			}
-		}
-	}
-
-	b, err = json.Marshal(quiz)
+	quiz, err := h.service.CreateQuiz(r.Context(), caller, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(201)
-	w.Write(b)
+	writeJSON(w, http.StatusCreated, quiz)
 }
 
 // updateQuiz
 // @Summary Update an existing quiz
-// @Description Updates the details of an existing quiz.
+// @Description Updates the details of an existing quiz. Only the author of the quiz and admins can update it.
 // @Tags Quizzes
 // @Accept json
 // @Produce json
 // @Param quiz body models.UpdateQuizRequest true "Updated quiz details"
 // @Success 200 {object} models.Quiz
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz not found"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
 // @Router /quizzes [patch]
 func (h *QuizHandler) updateQuiz(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => UpdateQuiz invoked", r.Method, r.URL.Path)
-	var request models.UpdateQuizRequest
-
-	b, err := io.ReadAll(r.Body)
+	caller, err := callerOf(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-
-	if err = json.Unmarshal(b, &request); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	request, err := readJSON[models.UpdateQuizRequest](r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	quiz := new(models.Quiz)
-	res := h.db.First(quiz, request.ID)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	quiz, err := h.service.UpdateQuiz(r.Context(), caller, request)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	if request.Name != nil && *request.Name != "" {
-		quiz.Name = *request.Name
-	}
+	writeJSON(w, http.StatusOK, quiz)
+}
 
-	res = h.db.Save(quiz)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+// readQuizzes
+// @Summary Get a list of quizzes
+// @Description Retrieves a paginated list of quizzes without their questions based on optional filters.
+// @Tags Quizzes
+// @Accept json
+// @Produce json
+// @Param idList query []uint32 false "List of quiz IDs" collectionFormat(multi)
+// @Param name query string false "Name to search for"
+// @Param status query string false "Only list quizzes with this status: draft, published or archived"
+// @Param sort query string false "Field to sort by: id, name or createdAt, prefixed with - for descending order"
+// @Param page query int false "Page number, starts at 1"
+// @Param size query int false "Page size, 20 by default and 100 at most"
+// @Success 200 {object} models.PaginationResponse
+// @Failure      400     {object}  models.ErrorResponse  "Malformed query parameters"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid query parameters"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Router /quizzes [get]
+func (h *QuizHandler) readQuizzes(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => ReadQuizzes invoked", r.Method, r.URL.Path)
+	request, err := readQuery[models.ReadQuizRequest](h.decoder, r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	b, err = json.Marshal(quiz)
+	response, err := h.service.ListQuizzes(r.Context(), request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
+	setPageLinks(r, response)
 
-	w.WriteHeader(200)
-	w.Write(b)
+	writeJSON(w, http.StatusOK, response)
 }
 
 // readQuizWithID
 // @Summary Get a quiz by ID
-// @Description Retrieves a quiz by its ID, including its questions and answers.
+// @Description Retrieves a quiz by its ID, including its questions and options. Takers get a models.QuizView without the answer key,
+// @Description the author of the quiz and admins get the full models.Quiz including which options are correct.
+// @Description Takers see the quiz as it was last published, the author and admins see it with the edits made since.
 // @Tags Quizzes
 // @Accept json
 // @Produce json
 // @Param id path string true "Quiz ID"
-// @Success 200 {object} models.Quiz
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Success 200 {object} models.QuizView
+// @Failure      400     {object}  models.ErrorResponse  "Malformed quiz id"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
 // @Router /quizzes/{id} [get]
 func (h *QuizHandler) readQuizWithID(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => ReadQuizWithID invoked", r.Method, r.URL.Path)
-	id := r.PathValue("id")
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
-	var quiz models.Quiz
-	res := h.db.Preload(clause.Associations).First(&quiz, id)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	quiz, full, err := h.service.GetQuiz(r.Context(), optionalCallerOf(r), id)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	b, err := json.Marshal(quiz)
+	if full {
+		writeJSON(w, http.StatusOK, quiz)
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewQuizView(quiz))
+}
+
+// readQuizVersions
+// @Summary List the versions of a quiz
+// @Description Lists every version of a quiz from the oldest to the newest without their snapshots.
+// @Description A version is saved whenever the quiz is published after it has been edited, progressions and scores keep the version they were taken on.
+// @Tags Quizzes
+// @Produce json
+// @Param id path string true "Quiz ID"
+// @Success 200 {array} models.QuizVersion
+// @Failure      400     {object}  models.ErrorResponse  "Malformed quiz id"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Router /quizzes/versions/{id} [get]
+func (h *QuizHandler) readQuizVersions(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => ReadQuizVersions invoked", r.Method, r.URL.Path)
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	versions, err := h.service.ListVersions(r.Context(), optionalCallerOf(r), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, versions)
+}
+
+// readQuizVersion
+// @Summary Get a version of a quiz
+// @Description Retrieves a version of a quiz by its number with the questions and options it had. Takers get the snapshot as a models.QuizView without the answer key,
+// @Description the author of the quiz and admins get the full models.Quiz including which options are correct.
+// @Tags Quizzes
+// @Produce json
+// @Param id path string true "Quiz ID"
+// @Param number path string true "Version number"
+// @Success 200 {object} models.QuizVersion
+// @Failure      400     {object}  models.ErrorResponse  "Malformed quiz id or version number"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz or version not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Router /quizzes/versions/{id}/{number} [get]
+func (h *QuizHandler) readQuizVersion(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => ReadQuizVersion invoked", r.Method, r.URL.Path)
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	number, err := pathID(r, "number")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	version, full, err := h.service.GetVersion(r.Context(), optionalCallerOf(r), id, number)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write(b)
+	if full {
+		writeJSON(w, http.StatusOK, version)
+		return
+	}
+	writeJSON(w, http.StatusOK, models.NewQuizVersionView(version))
 }
 
 // deleteQuiz
 // @Summary Delete a quiz
-// @Description Deletes a quiz by its ID, including its associated questions and answers.
+// @Description Deletes a quiz by its ID together with its questions, options and versions and every progression, answer and score taken on it. Only the author of the quiz and admins can delete it.
 // @Tags Quizzes
 // @Accept json
 // @Produce json
 // @Param id path string true "Quiz ID"
 // @Success 204 "No Content"
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed quiz id"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
 // @Router /quizzes/{id} [delete]
 func (h *QuizHandler) deleteQuiz(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => DeleteQuiz invoked", r.Method, r.URL.Path)
-	id := r.PathValue("id")
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
-	res := h.db.Select(clause.Associations).Delete(&models.Quiz{}, id)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	if err = h.service.DeleteQuiz(r.Context(), caller, id); err != nil {
+		writeError(w, err)
 		return
 	}
 
 	w.WriteHeader(204)
 }
 
+// publishQuiz
+// @Summary Publish a quiz
+// @Description Makes a quiz available to takers after checking that every question can be answered and scored:
+// @Description single and multiple questions need a correct option, ordering and matching questions at least 2 options and text and numeric questions their answer key.
+// @Description The quiz is saved as a new version unless it has not changed since it was last published. Archived quizzes can be published again.
+// @Tags Quizzes
+// @Produce json
+// @Param id path string true "Quiz ID"
+// @Success 200 {object} models.Quiz
+// @Failure      400     {object}  models.ErrorResponse  "Malformed quiz id"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz not found"
+// @Failure      409     {object}  models.ErrorResponse  "Quiz does not have any questions"
+// @Failure      422     {object}  models.ErrorResponse  "Questions that cannot be answered or scored, listed in details"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/{id}/publish [post]
+func (h *QuizHandler) publishQuiz(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => PublishQuiz invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	quiz, err := h.service.Publish(r.Context(), caller, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, quiz)
+}
+
+// archiveQuiz
+// @Summary Archive a quiz
+// @Description Stops a quiz from being begun. Attempts already begun can still be finished and every score is kept.
+// @Tags Quizzes
+// @Produce json
+// @Param id path string true "Quiz ID"
+// @Success 200 {object} models.Quiz
+// @Failure      400     {object}  models.ErrorResponse  "Malformed quiz id"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/{id}/archive [post]
+func (h *QuizHandler) archiveQuiz(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => ArchiveQuiz invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	quiz, err := h.service.Archive(r.Context(), caller, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, quiz)
+}
+
 // beginQuiz
 // @Summary Begin a quiz
-// @Description Starts a quiz session for a user, initializing the progression with the first question.
+// @Description Starts a quiz session for the authenticated user, initializing the progression with the first question.
+// @Description Quizzes with shuffling turned on give every progression its own seeded order of questions and options.
+// @Description Only published quizzes can be begun, the progression takes the version saved when the quiz was last published.
+// @Description When the quiz or its first question has a time limit the response tells how many seconds are left.
+// @Description The progression carries the number of the attempt, quizzes can limit the attempts of a user and the time between beginning two of them.
 // @Tags Quizzes
 // @Accept json
 // @Produce json
 // @Param beginQuiz body models.BeginQuizRequest true "Quiz start details"
 // @Success 201 {object} models.BeginQuizResponse
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz not found"
+// @Failure      409     {object}  models.ErrorResponse  "Quiz is not published or does not have any questions, no attempts are left or the cooldown has not passed"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
 // @Router /quizzes/begin [post]
 func (h *QuizHandler) beginQuiz(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => BeginQuiz invoked", r.Method, r.URL.Path)
-	request, err := util.ReadBodyAndUnmarshal(models.BeginQuizRequest{}, r.Body)
+	caller, err := callerOf(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-
-	// Get quiz and check if it is okay to start progressing on it
-	var quiz models.Quiz
-	res := h.db.Preload(clause.Associations).First(&quiz, request.QuizID)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
-		return
-	}
-	if len(quiz.Questions) < 1 {
-		http.Error(w, "quizHandler: quiz does not have any questions,", http.StatusInternalServerError)
-		return
-	}
-
-	// Create a new progression for user to keep track of where we are at
-	progression := models.Progression{
-		UserID:            request.UserID,
-		QuizID:            request.QuizID,
-		IsFinished:        false,
-		CurrentQuestionID: quiz.Questions[0].ID,
-		QuestionNumber:    0,
-	}
-	res = h.db.Create(&progression)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	request, err := readJSON[models.BeginQuizRequest](r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	response := models.BeginQuizResponse{
-		Progression: progression,
-	}
-	b, err := json.Marshal(response)
+	progression, question, err := h.service.Begin(r.Context(), caller, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(201)
-	w.Write(b)
+	writeJSON(w, http.StatusCreated, models.BeginQuizResponse{
+		Progression:     *progression,
+		CurrentQuestion: questionViewOf(question),
+		TimeRemaining:   models.NewTimeRemaining(progression, time.Now()),
+	})
 }
 
 // answerQuizQuestion
 // @Summary Answer a quiz question
-// @Description Submits an answer for the current question and updates the progression.
+// @Description Submits an answer for the current question and updates the progression. Only the user who began the progression can answer it.
+// @Description Single questions are answered with optionId, multiple questions with the set of optionIds, text questions with text and numeric questions with number.
+// @Description Ordering questions are answered with every option id in order and matching questions with pairs of option ids and the items they match.
+// @Description The response carries the next question in the order of the progression until every question is answered.
+// @Description An answer after the current question ran out of time is not saved and the progression moves on to the next question,
+// @Description an answer after the quiz ran out of time is not saved and the progression is submitted with the answers given until then.
+// @Description Both set timedOut, the latter also carries the score.
 // @Tags Quizzes
 // @Accept json
 // @Produce json
 // @Param answerQuizQuestion body models.AnswerQuizQuestionRequest true "Answer details"
 // @Success 200 {object} models.AnswerQuizQuestionResponse
-// @Failure 400 {string} string "Bad Request"
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Progression belongs to another user"
+// @Failure      404     {object}  models.ErrorResponse  "Progression or question not found"
+// @Failure      409     {object}  models.ErrorResponse  "Quiz is already finished"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields or the answer does not fit the current question"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
 // @Router /quizzes/answer [post]
 func (h *QuizHandler) answerQuizQuestion(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => AnswerQuizQuestion invoked", r.Method, r.URL.Path)
-	request, err := util.ReadBodyAndUnmarshal(models.AnswerQuizQuestionRequest{}, r.Body)
+	caller, err := callerOf(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-
-	// Get progression to check if it is okay to answer new questions
-	// if it is ok, get question that we are going to answer
-	var progression models.Progression
-	res := h.db.First(&progression, request.ProgressionID)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	request, err := readJSON[models.AnswerQuizQuestionRequest](r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	if progression.IsFinished {
-		http.Error(w, "quizHandler: quiz is already finished", http.StatusBadRequest)
+	answered, err := h.service.Answer(r.Context(), caller, request)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	// check if question belongs to the quiz that is being done
-	// check if question has that option that user is trying to select
-	// if all good select option and save answer
-	var question models.Question
-	res = h.db.Preload("Options").First(&question, progression.CurrentQuestionID)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
-		return
+	writeJSON(w, http.StatusOK, models.AnswerQuizQuestionResponse{
+		Progression:     *answered.Progression,
+		CurrentQuestion: questionViewOf(answered.Next),
+		TimedOut:        answered.TimedOut,
+		Score:           answered.Score,
+		TimeRemaining:   models.NewTimeRemaining(answered.Progression, time.Now()),
+	})
+}
+
+// questionViewOf returns the taker view of the question, or nil when there is no question
+func questionViewOf(question *models.Question) *models.QuestionView {
+	if question == nil {
+		return nil
 	}
+	view := models.NewQuestionView(question)
+	return &view
+}
 
-	if question.QuizID != progression.QuizID {
-		http.Error(w, "quizHandler: question does not belong to this quiz", http.StatusBadRequest)
+// finalizeQuiz
+// @Summary Finalize a quiz
+// @Description Marks a quiz as finished and calculates the score based on correct answers. Only the user who began the progression can submit it.
+// @Description Every question earns its points times the credit of its answer, minus the penalties of the quiz for wrong and unanswered questions.
+// @Description The score carries the raw and maximum points, the percentage and whether it reached the pass threshold of the quiz.
+// @Tags Quizzes
+// @Accept json
+// @Produce json
+// @Param finalizeQuiz body models.FinalizeQuizRequest true "Quiz finalization details"
+// @Success 200 {object} models.FinalizeQuizResponse
+// @Failure      400     {object}  models.ErrorResponse  "Malformed request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Progression belongs to another user"
+// @Failure      404     {object}  models.ErrorResponse  "Progression or quiz not found"
+// @Failure      409     {object}  models.ErrorResponse  "Quiz does not have any questions or progression is already submitted"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/submit [post]
+func (h *QuizHandler) calculateScore(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => CalculateScore invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-
-	isOptionInQuestion := false
-	for _, o := range question.Options {
-		if o.ID == request.OptionID {
-			isOptionInQuestion = true
-			break
-		}
+	request, err := readJSON[models.FinalizeQuizRequest](r)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
-	if !isOptionInQuestion {
-		http.Error(w, "quizHandler: chosen option does not belong to this question", http.StatusBadRequest)
+
+	score, err := h.service.Submit(r.Context(), caller, request)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	// Save new answer to db
-	answer := models.Answer{
-		UserID:   progression.UserID,
-		OptionID: request.OptionID,
-		QuizID:   progression.QuizID,
+	writeJSON(w, http.StatusOK, models.FinalizeQuizResponse{
+		Score: *score,
+	})
+}
+
+// getQuestion
+// @Summary Get a quiz question by ID
+// @Description Retrieves a question by its ID along with its answer options. Takers get a models.QuestionView without the answer key,
+// @Description the author of the quiz and admins get the full models.Question including which options are correct.
+// @Description Given a progression of the caller, the options are ordered the way that progression sees them.
+// @Tags Quizzes
+// @Accept json
+// @Produce json
+// @Param id path string true "Question ID"
+// @Param progressionId query int false "Progression to order the options for"
+// @Success 200 {object} models.QuestionView
+// @Failure      400     {object}  models.ErrorResponse  "Malformed question id or query"
+// @Failure      401     {object}  models.ErrorResponse  "Progression given without a valid bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Progression belongs to another user"
+// @Failure      404     {object}  models.ErrorResponse  "Question or progression not found"
+// @Failure      409     {object}  models.ErrorResponse  "Question does not belong to the quiz of the progression"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Router /quizzes/questions/{id} [get]
+func (h *QuizHandler) getQuestion(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => GetQuestion invoked", r.Method, r.URL.Path)
+	questionId, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
 	}
-	res = h.db.Create(&answer)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	request, err := readQuery[models.ReadQuestionRequest](h.decoder, r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	// Get quiz to fetch new question for progression or finish the progression
-	var quiz models.Quiz
-	res = h.db.Preload(clause.Associations).First(&quiz, progression.QuizID)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
+	// a progression can only be read for by its owner, anonymous callers are told to authenticate
+	caller := optionalCallerOf(r)
+	if request.ProgressionID != 0 {
+		if caller, err = callerOf(r); err != nil {
+			writeError(w, err)
 			return
 		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	}
+	question, full, err := h.service.GetQuestion(r.Context(), caller, questionId, request)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	progression.QuestionNumber++
-	if len(quiz.Questions) > progression.QuestionNumber {
-		progression.CurrentQuestionID = quiz.Questions[progression.QuestionNumber].ID
-	} else {
-		progression.IsFinished = true
+	if full {
+		writeJSON(w, http.StatusOK, question)
+		return
 	}
+	writeJSON(w, http.StatusOK, models.NewQuestionView(question))
+}
 
-	// save progression
-	res = h.db.Save(&progression)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+// @Summary      Create a question option
+// @Description  Creates a new option for a specific question by its ID. Only the author of the quiz and admins can add options.
+// @Tags         Quiz
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                    true  "Question ID"
+// @Param        request body      models.CreateOptionRequest true  "Option creation payload"
+// @Success      201     {object}  models.OptionBase         "Created option"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed question id or request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Question not found"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields, a text or numeric question, or a correct option on a question other than single or multiple"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/questions/{id}/options [post]
+func (h *QuizHandler) createQuestionOption(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => CreateQuestionOption invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-
-	response := models.AnswerQuizQuestionResponse{
-		Progression: progression,
+	questionId, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
 	}
-	b, err := json.Marshal(response)
+	request, err := readJSON[models.CreateOptionRequest](r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write(b)
+	option, err := h.service.CreateOption(r.Context(), caller, questionId, request)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, option.OptionBase)
 }
 
-// finalizeQuiz
-// @Summary Finalize a quiz
-// @Description Marks a quiz as finished and calculates the score based on correct answers.
+// createQuestion
+// @Summary Add a question to a quiz
+// @Description Appends a question with its optional options to a quiz. Only the author of the quiz and admins can add questions.
+// @Description Text questions carry their acceptedAnswers and numeric questions their numericAnswer instead of options.
+// @Description Options of ordering questions are given in their correct order and options of matching questions with the pair they match.
 // @Tags Quizzes
 // @Accept json
 // @Produce json
-// @Param finalizeQuiz body models.FinalizeQuizRequest true "Quiz finalization details"
-// @Success 200 {object} models.FinalizeQuizResponse
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
-// @Router /quizzes/submit [post]
-func (h *QuizHandler) calculateScore(w http.ResponseWriter, r *http.Request) {
-	log.Printf("%s %s => CalculateScore invoked", r.Method, r.URL.Path)
-	request, err := util.ReadBodyAndUnmarshal(models.FinalizeQuizRequest{}, r.Body)
+// @Param id path string true "Quiz ID"
+// @Param question body models.CreateQuestionRequest true "Question details"
+// @Success 201 {object} models.Question
+// @Failure      400     {object}  models.ErrorResponse  "Malformed quiz id or request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz not found"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/{id}/questions [post]
+func (h *QuizHandler) createQuestion(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => CreateQuestion invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-
-	var progression models.Progression
-	res := h.db.Preload(clause.Associations).First(&progression, request.ProgressionID)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	quizId, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-	progression.IsFinished = true
-
-	var quiz models.Quiz
-	res = h.db.Preload(clause.Associations).First(&quiz, progression.QuizID)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	request, err := readJSON[models.CreateQuestionRequest](r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	totalQuestionCount := len(quiz.Questions)
-	if totalQuestionCount == 0 {
-		http.Error(w, "quizHandler: quiz does not have any questions", http.StatusInternalServerError)
+	question, err := h.service.CreateQuestion(r.Context(), caller, quizId, request)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-	// get answers given to the quiz by the user
-	var answers []models.Answer
-	res = h.db.Where("user_id = ? AND quiz_id = ?", progression.UserID, progression.QuizID).Find(&answers)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+
+	writeJSON(w, http.StatusCreated, question)
+}
+
+// updateQuestion
+// @Summary Update a question
+// @Description Changes the text, type, scoring, points or answer key of a question. Only the author of the quiz and admins can update it.
+// @Description The type of a question can only change while it has no options.
+// @Description The change goes into the next version of the quiz, progressions and scores keep the version they were taken on.
+// @Tags Quizzes
+// @Accept json
+// @Produce json
+// @Param id path string true "Question ID"
+// @Param question body models.UpdateQuestionRequest true "Updated question details"
+// @Success 200 {object} models.Question
+// @Failure      400     {object}  models.ErrorResponse  "Malformed question id or request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Question not found"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/questions/{id} [patch]
+func (h *QuizHandler) updateQuestion(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => UpdateQuestion invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-	optionIds := make([]uint32, len(answers))
-	for i, a := range answers {
-		optionIds[i] = a.OptionID
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
 	}
-	// get options where answers belong to user and quiz
-	var options []models.Option
-	res = h.db.Where("id IN ?", optionIds).Find(&options)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	request, err := readJSON[models.UpdateQuestionRequest](r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	correctAnswerCount := 0
-	for _, o := range options {
-		if o.IsCorrect {
-			correctAnswerCount++
-		}
-	}
-	calculatedScore := float32(correctAnswerCount) / float32(totalQuestionCount)
-	score := models.Score{
-		QuizID: progression.QuizID,
-		UserID: progression.UserID,
-		Score:  calculatedScore,
-	}
-	res = h.db.Create(&score)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	question, err := h.service.UpdateQuestion(r.Context(), caller, id, request)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	// Quiz has been submitted so progression is not needed anymore
-	res = h.db.Delete(&models.Progression{}, progression.ID)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	writeJSON(w, http.StatusOK, question)
+}
+
+// deleteQuestion
+// @Summary Delete a question
+// @Description Deletes a question along with its options. Only the author of the quiz and admins can delete it.
+// @Tags Quizzes
+// @Produce json
+// @Param id path string true "Question ID"
+// @Success 204 "No Content"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed question id"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Question not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/questions/{id} [delete]
+func (h *QuizHandler) deleteQuestion(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => DeleteQuestion invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-	response := models.FinalizeQuizResponse{
-		Score: score,
-	}
-	b, err := json.Marshal(response)
+	id, err := pathID(r, "id")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write(b)
+	if err = h.service.DeleteQuestion(r.Context(), caller, id); err != nil {
+		writeError(w, err)
+		return
+	}
 
+	w.WriteHeader(204)
 }
 
-// getQuestion
-// @Summary Get a quiz question by ID
-// @Description Retrieves a question by its ID along with its answer options.
+// updateOption
+// @Summary Update an option
+// @Description Changes the value, correctness, step or pair of an option. Only the author of the quiz and admins can update it.
+// @Description Only options of single and multiple questions can be marked correct.
 // @Tags Quizzes
 // @Accept json
 // @Produce json
-// @Param id path string true "Question ID"
-// @Success 200 {object} models.QuestionWithOptionsResponse
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
-// @Router /quizzes/questions/{id} [get]
-func (h *QuizHandler) getQuestion(w http.ResponseWriter, r *http.Request) {
-	log.Printf("%s %s => GetQuestion invoked", r.Method, r.URL.Path)
-	questionId := r.PathValue("id")
-
-	var question models.Question
-	res := h.db.Preload("Options").First(&question, questionId)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+// @Param id path string true "Option ID"
+// @Param option body models.UpdateOptionRequest true "Updated option details"
+// @Success 200 {object} models.Option
+// @Failure      400     {object}  models.ErrorResponse  "Malformed option id or request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Option not found"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/options/{id} [patch]
+func (h *QuizHandler) updateOption(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => UpdateOption invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	request, err := readJSON[models.UpdateOptionRequest](r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	options := make([]models.OptionBase, len(question.Options))
-	for i, o := range question.Options {
-		options[i] = models.OptionBase{
-			Base:       o.Base,
-			QuestionID: o.QuestionID,
-			Value:      o.Value,
-		}
+	option, err := h.service.UpdateOption(r.Context(), caller, id, request)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
 
-	response := models.QuestionWithOptionsResponse{
-		Base:     question.Base,
-		Question: question.Question,
-		Answers:  options,
-		QuizID:   question.QuizID,
+	writeJSON(w, http.StatusOK, option)
+}
+
+// deleteOption
+// @Summary Delete an option
+// @Description Deletes an option of a question. Only the author of the quiz and admins can delete it.
+// @Tags Quizzes
+// @Produce json
+// @Param id path string true "Option ID"
+// @Success 204 "No Content"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed option id"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Option not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/options/{id} [delete]
+func (h *QuizHandler) deleteOption(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => DeleteOption invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
-	b, err := json.Marshal(response)
+	id, err := pathID(r, "id")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write(b)
+	if err = h.service.DeleteOption(r.Context(), caller, id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(204)
 }
 
-// @Summary      Create a question option
-// @Description  Creates a new option for a specific question by its ID.
-// @Tags         Quiz
-// @Accept       json
-// @Produce      json
-// @Param        id      path      string                    true  "Question ID"
-// @Param        request body      models.CreateOptionRequest true  "Option creation payload"
-// @Success      201     {object}  models.OptionBase         "Created option"
-// @Failure      400     {string}  string                    "Bad request"
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
-// @Router /quizzes/questions/{id}/options [post]
-func (h *QuizHandler) createQuestionOption(w http.ResponseWriter, r *http.Request) {
-	log.Printf("%s %s => CreateQuestionOption invoked", r.Method, r.URL.Path)
-	questionId := r.PathValue("id")
-	request, err := util.ReadBodyAndUnmarshal(models.CreateOptionRequest{}, r.Body)
+// reorderQuestions
+// @Summary Reorder the questions of a quiz
+// @Description Arranges the questions of a quiz in the order of the given ids, which must list every question of the quiz once. Only the author of the quiz and admins can reorder it.
+// @Tags Quizzes
+// @Accept json
+// @Produce json
+// @Param id path string true "Quiz ID"
+// @Param order body models.ReorderRequest true "Question ids in their new order"
+// @Success 200 {object} models.Quiz
+// @Failure      400     {object}  models.ErrorResponse  "Malformed quiz id or request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz not found"
+// @Failure      422     {object}  models.ErrorResponse  "Ids are not the questions of the quiz"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/{id}/questions/order [put]
+func (h *QuizHandler) reorderQuestions(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => ReorderQuestions invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	quizId, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	request, err := readJSON[models.ReorderRequest](r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	var question models.Question
-	res := h.db.First(&question, questionId)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	quiz, err := h.service.ReorderQuestions(r.Context(), caller, quizId, request)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	option := models.Option{
-		OptionBase: models.OptionBase{
-			QuestionID: question.ID,
-			Value:      request.Value,
-		},
-		IsCorrect: request.IsCorrect,
+	writeJSON(w, http.StatusOK, quiz)
+}
+
+// reorderOptions
+// @Summary Reorder the options of a question
+// @Description Arranges the options of a question in the order of the given ids, which must list every option of the question once. Only the author of the quiz and admins can reorder them.
+// @Tags Quizzes
+// @Accept json
+// @Produce json
+// @Param id path string true "Question ID"
+// @Param order body models.ReorderRequest true "Option ids in their new order"
+// @Success 200 {object} models.Question
+// @Failure      400     {object}  models.ErrorResponse  "Malformed question id or request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Only the author of the quiz and admins can change it"
+// @Failure      404     {object}  models.ErrorResponse  "Question not found"
+// @Failure      422     {object}  models.ErrorResponse  "Ids are not the options of the question"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
+// @Router /quizzes/questions/{id}/options/order [put]
+func (h *QuizHandler) reorderOptions(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s => ReorderOptions invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
-	res = h.db.Create(&option)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	questionId, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-	b, err := json.Marshal(option.OptionBase)
+	request, err := readJSON[models.ReorderRequest](r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	question, err := h.service.ReorderOptions(r.Context(), caller, questionId, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(201)
-	w.Write(b)
+	writeJSON(w, http.StatusOK, question)
 }
@@ -1,26 +1,21 @@
 package handlers
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
 	"log"
 	"net/http"
-	"strconv"
 
 	"github.com/gorilla/schema"
 	"github.com/lghtr35/quiz-maker/models"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
+	"github.com/lghtr35/quiz-maker/service"
 )
 
 type UserHandler struct {
-	db      *gorm.DB
-	decoder schema.Decoder
+	service *service.UserService
+	decoder *schema.Decoder
 }
 
-func newUserHandler(db *gorm.DB) *UserHandler {
-	return &UserHandler{db: db, decoder: *schema.NewDecoder()}
+func newUserHandler(s *service.UserService) *UserHandler {
+	return &UserHandler{service: s, decoder: newQueryDecoder()}
 }
 
 func (h *UserHandler) ConfigureSelf(m *http.ServeMux) *http.ServeMux {
@@ -38,226 +33,174 @@ func (h *UserHandler) ConfigureSelf(m *http.ServeMux) *http.ServeMux {
 
 // readUsers
 // @Summary Get a list of users
-// @Description Retrieves a paginated list of users based on optional filters.
+// @Description Retrieves a paginated list of users based on optional filters. Pages are selected either by page number or by the nextCursor of a previous page, which stays fast on large user tables.
 // @Tags Users
 // @Accept json
 // @Produce json
-// @Param id_list query []uint32 false "List of user IDs"
+// @Param idList query []uint32 false "List of user IDs" collectionFormat(multi)
 // @Param name query string false "Name to search for"
-// @Param page query int true "Page number"
-// @Param size query int true "Page size"
-// @Success 200 {array} models.User
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Param role query string false "Role to filter by: admin, author or taker"
+// @Param page query int false "Page number, starts at 1"
+// @Param size query int false "Page size, 20 by default and 100 at most"
+// @Param cursor query string false "nextCursor of a previous page, used instead of page"
+// @Success 200 {object} models.PaginationResponse
+// @Failure      400     {object}  models.ErrorResponse  "Malformed query parameters"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid query parameters or cursor"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
 // @Router /users [get]
 func (h *UserHandler) readUsers(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => ReadUsers invoked", r.Method, r.URL.Path)
-	var request models.ReadUsersRequest
-	err := h.decoder.Decode(&request, r.URL.Query())
+	request, err := readQuery[models.ReadUsersRequest](h.decoder, r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	var users []models.User
-	q := h.db.Model(&models.User{})
-	if request.IDList != nil && len(*request.IDList) > 0 {
-		q = q.Where("ID IN ?", *request.IDList)
-	}
-	if request.Name != nil && *request.Name != "" {
-		// obtain a search string like '%name%'
-		nameLike := fmt.Sprintf("%%%s%%", *request.Name)
-		q = q.Where("name LIKE ?", nameLike)
-	}
-	offset := (request.Page - 1) * request.Size
-	q = q.Offset(int(offset)).Limit(int(request.Size))
-	res := q.Preload(clause.Associations).Find(&users)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	b, err := json.Marshal(users)
+	response, err := h.service.ListUsers(r.Context(), request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-	w.WriteHeader(200)
-	w.Write(b)
+	setPageLinks(r, response)
+
+	writeJSON(w, http.StatusOK, response)
 }
 
 // createUsers
 // @Summary Create a new user
-// @Description Creates a new user in the system.
+// @Description Registers a new user with a password. Users always register as takers, operators make the first admin with the admin command.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param user body models.CreateUserRequest true "User details"
 // @Success 201 {object} models.User
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed request body"
+// @Failure      409     {object}  models.ErrorResponse  "User name is already taken"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
 // @Router /users [post]
 func (h *UserHandler) createUsers(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => CreateUsers invoked", r.Method, r.URL.Path)
-	var request models.CreateUserRequest
-
-	b, err := io.ReadAll(r.Body)
+	request, err := readJSON[models.CreateUserRequest](r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	if err = json.Unmarshal(b, &request); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	user := &models.User{
-		Name: request.Name,
-	}
-	res := h.db.Create(user)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	b, err = json.Marshal(user)
+	user, err := h.service.CreateUser(r.Context(), request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(201)
-	w.Write(b)
+	writeJSON(w, http.StatusCreated, user)
 }
 
 // updateUsers
 // @Summary Update an existing user
-// @Description Updates user details based on the provided request body.
+// @Description Updates the name or password of a user. Users can only update themselves, admins can update everyone and change roles.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param user body models.UpdateUserRequest true "Updated user details"
 // @Success 200 {object} models.User
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed request body"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Users can only change their own account and only admins can change roles"
+// @Failure      404     {object}  models.ErrorResponse  "User not found"
+// @Failure      422     {object}  models.ErrorResponse  "Invalid request fields"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
 // @Router /users [patch]
 func (h *UserHandler) updateUsers(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => UpdateUsers invoked", r.Method, r.URL.Path)
-	var request models.UpdateUserRequest
-
-	b, err := io.ReadAll(r.Body)
+	caller, err := callerOf(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-
-	if err = json.Unmarshal(b, &request); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	user := new(models.User)
-	res := h.db.First(user, request.ID)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if request.Name != nil && *request.Name != "" {
-		user.Name = *request.Name
-	}
-
-	res = h.db.Save(user)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	request, err := readJSON[models.UpdateUserRequest](r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	b, err = json.Marshal(user)
+	user, err := h.service.UpdateUser(r.Context(), caller, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write(b)
+	writeJSON(w, http.StatusOK, user)
 }
 
 // readUserWithID
 // @Summary Get a user by ID
-// @Description Retrieves a user by their ID.
+// @Description Retrieves a user by their ID along with their answers. Users can only read themselves, admins can read everyone.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
 // @Success 200 {object} models.User
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed user id"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Users can only access their own account"
+// @Failure      404     {object}  models.ErrorResponse  "User not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
 // @Router /users/{id} [get]
 func (h *UserHandler) readUserWithID(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => ReadUserWithID invoked", r.Method, r.URL.Path)
-	id := r.PathValue("id")
-
-	var user models.User
-	res := h.db.Preload(clause.Associations).First(&user, id)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	b, err := json.Marshal(user)
+	user, err := h.service.GetUser(r.Context(), caller, id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write(b)
+	writeJSON(w, http.StatusOK, user)
 }
 
 // deleteUser
 // @Summary Delete a user
-// @Description Deletes a user by their ID.
+// @Description Deletes a user by their ID. Users can only delete themselves, admins can delete everyone.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
 // @Success 204 "No Content"
-// @Failure      404     {string}  string                    "Question not found"
-// @Failure      500     {string}  string                    "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed user id"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Users can only access their own account"
+// @Failure      404     {object}  models.ErrorResponse  "User not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security BearerAuth
 // @Router /users/{id} [delete]
 func (h *UserHandler) deleteUser(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => DeleteUser invoked", r.Method, r.URL.Path)
-	id := r.PathValue("id")
-
-	res := h.db.Delete(&models.User{}, id)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err = h.service.DeleteUser(r.Context(), caller, id); err != nil {
+		writeError(w, err)
 		return
 	}
 
@@ -266,193 +209,151 @@ func (h *UserHandler) deleteUser(w http.ResponseWriter, r *http.Request) {
 
 // readUserScoreForQuiz godoc
 // @Summary      Get user's score for a specific quiz
-// @Description  Retrieves the user's score for a specific quiz.
+// @Description  Retrieves the user's score for a specific quiz, optionally on a single version of it. Only the user, the author of the quiz and admins can see it.
+// @Description  When the user took the quiz more than once, the score policy of the quiz decides whether the best, latest, first or average score counts.
 // @Tags         Users
 // @Param        userId  path      string  true  "User ID"
 // @Param        quizId  path      string  true  "Quiz ID"
+// @Param        version query     int     false "Number of the version of the quiz"
+// @Param        attempt query     int     false "Number of the attempt, the score the score policy of the quiz counts by default"
 // @Success      200     {object}  models.Score
-// @Failure      500     {string}  string  "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed user or quiz id or query"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Takers can only see their own results"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz, score, version or attempt not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
 // @Router       /users/{userId}/quiz/{quizId}  [get]
 func (h *UserHandler) readUserScoreForQuiz(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => GetUserScoreForQuiz invoked", r.Method, r.URL.Path)
-	userId := r.PathValue("userId")
-	quizId := r.PathValue("quizId")
-
-	var score models.Score
-	res := h.db.Where("user_id = ? AND quiz_id = ?", userId, quizId).First(&score)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	userId, quizId, ok := userAndQuizIDs(w, r)
+	if !ok {
 		return
 	}
 
-	b, err := json.Marshal(score)
+	request, err := readQuery[models.ReadResultRequest](h.decoder, r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write(b)
+	score, err := h.service.GetScore(r.Context(), caller, userId, quizId, request)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, score)
 }
 
 // readUserRankingByScore godoc
 // @Summary      Get user's ranking by score in a specific quiz
-// @Description  Retrieves the user's ranking, score, and percentage of quizzers they outperformed in a specific quiz.
+// @Description  Retrieves the user's ranking, score, and percentage of quizzers they outperformed in a specific quiz. Only the user, the author of the quiz and admins can see it.
+// @Description  Given a version, only the scores of that version of the quiz are ranked. Every user is ranked by the score the score policy of the quiz counts.
 // @Tags         Users
 // @Param        userId  path      string  true  "User ID"
 // @Param        quizId  path      string  true  "Quiz ID"
+// @Param        version query     int     false "Number of the version of the quiz"
 // @Success      200     {object}  models.ReadUserRankingByScoreResponse
-// @Failure      500     {string}  string  "Internal server error"
+// @Failure      400     {object}  models.ErrorResponse  "Malformed user or quiz id or query"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Takers can only see their own results"
+// @Failure      404     {object}  models.ErrorResponse  "Quiz or version not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
 // @Router       /users/{userId}/quiz/{quizId}/ranking [get]
 func (h *UserHandler) readUserRankingByScore(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s => GetUserRankingByScore invoked", r.Method, r.URL.Path)
-	userIdStr := r.PathValue("userId")
-	quizId := r.PathValue("quizId")
-
-	temp, err := strconv.ParseUint(userIdStr, 10, 32)
+	caller, err := callerOf(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-	userId := uint32(temp)
-
-	var scores []models.Score
-	//Query
-	res := h.db.Where("quiz_id = ?", quizId).Order("score desc").Find(&scores)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	userId, quizId, ok := userAndQuizIDs(w, r)
+	if !ok {
 		return
 	}
 
-	totalOpponentCount := len(scores)
-	userPlace := 0
-	var userScore models.Score
-	for i, s := range scores {
-		if s.UserID == userId {
-			userPlace = i + 1
-			userScore = scores[i]
-			break
-		}
-	}
-	percent := (1 - (float32(userPlace) / float32(totalOpponentCount))) * 100
-	response := models.ReadUserRankingByScoreResponse{
-		Percent: percent,
-		Message: fmt.Sprintf("You were better than %.2f%% of all quizzers", percent),
-		Score:   userScore,
-		Rank:    uint32(userPlace),
-	}
-
-	if percent == 0 && userPlace == 1 {
-		response.Message = "You were the only person to finish this quiz yet."
-	}
-
-	if userPlace == 0 {
-		response.Message = "Score of this quiz has not been found."
-		response.Percent = 0
-		response.Score = models.Score{}
-		response.Rank = 0
+	request, err := readQuery[models.ReadResultRequest](h.decoder, r)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
 
-	b, err := json.Marshal(response)
+	response, err := h.service.GetRanking(r.Context(), caller, userId, quizId, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write(b)
+	writeJSON(w, http.StatusOK, response)
 }
 
+// readUserScoreAnalysis godoc
+// @Summary      Get analysis of user's score in a specific quiz
+// @Description  Retrieves the user's score along with the options they chose and the credit every question earned. Only the user, the author of the quiz and admins can see it.
+// @Description  Takers get a models.ScoreAnalysisView without the answer key, the author of the quiz and admins get the full models.ReadUserScoreAnalysis including the correct options.
+// @Description  The quiz is shown as in the version the score was calculated on, a version can be given to analyse the score of that version.
+// @Tags         Users
+// @Param        userId  path      string  true  "User ID"
+// @Param        quizId  path      string  true  "Quiz ID"
+// @Param        version query     int     false "Number of the version of the quiz"
+// @Param        attempt query     int     false "Number of the attempt to analyse, the one the score policy of the quiz counts by default"
+// @Success      200     {object}  models.ScoreAnalysisView
+// @Failure      400     {object}  models.ErrorResponse  "Malformed user or quiz id or query"
+// @Failure      401     {object}  models.ErrorResponse  "Missing, invalid or expired bearer token"
+// @Failure      403     {object}  models.ErrorResponse  "Takers can only see their own results"
+// @Failure      404     {object}  models.ErrorResponse  "User, quiz, version, score or attempt not found"
+// @Failure      500     {object}  models.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
+// @Router       /users/{userId}/quiz/{quizId}/analysis [get]
 func (h *UserHandler) readUserScoreAnalysis(w http.ResponseWriter, r *http.Request) {
-	log.Printf("%s %s => GetUserRankingByScore invoked", r.Method, r.URL.Path)
-	uId := r.PathValue("userId")
-	qId := r.PathValue("quizId")
-
-	var user models.User
-	res := h.db.Preload("Answers", "quiz_id = ?", qId).First(&user, uId)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	log.Printf("%s %s => GetUserScoreAnalysis invoked", r.Method, r.URL.Path)
+	caller, err := callerOf(r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-
-	var quiz models.Quiz
-	res = h.db.Preload("Questions").First(&quiz, qId)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	userId, quizId, ok := userAndQuizIDs(w, r)
+	if !ok {
 		return
 	}
 
-	optIds := make([]uint, len(user.Answers))
-	for i, a := range user.Answers {
-		optIds[i] = uint(a.OptionID)
-	}
-
-	var userOptions []models.Option
-	res = h.db.Find(&userOptions, optIds)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	request, err := readQuery[models.ReadResultRequest](h.decoder, r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	correctOptions := make([]models.Option, 0)
-	for _, question := range quiz.Questions {
-		var optionsFound []models.Option
-		res = h.db.Where("is_correct = ? AND question_id = ?", true, question.ID).Find(&optionsFound)
-		if res.Error != nil {
-			if res.Error == gorm.ErrRecordNotFound {
-				http.Error(w, res.Error.Error(), http.StatusNotFound)
-				return
-			}
-			http.Error(w, res.Error.Error(), http.StatusInternalServerError)
-			return
-		}
-		correctOptions = append(correctOptions, optionsFound...)
+	response, full, err := h.service.GetScoreAnalysis(r.Context(), caller, userId, quizId, request)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
 
-	var score models.Score
-	res = h.db.Where("user_id = ? AND quiz_id = ?", uId, qId).First(&score)
-	if res.Error != nil {
-		if res.Error == gorm.ErrRecordNotFound {
-			http.Error(w, res.Error.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, res.Error.Error(), http.StatusInternalServerError)
+	if full {
+		writeJSON(w, http.StatusOK, response)
 		return
 	}
+	writeJSON(w, http.StatusOK, models.NewScoreAnalysisView(response))
+}
 
-	response := models.ReadUserScoreAnalysis{
-		User:           user,
-		Quiz:           quiz,
-		Score:          score,
-		UserAnswers:    userOptions,
-		CorrectAnswers: correctOptions,
+// userAndQuizIDs parses the userId and quizId path values, writing an invalid_id error when either is malformed
+func userAndQuizIDs(w http.ResponseWriter, r *http.Request) (uint32, uint32, bool) {
+	userId, err := pathID(r, "userId")
+	if err != nil {
+		writeError(w, err)
+		return 0, 0, false
 	}
-	b, err := json.Marshal(response)
+	quizId, err := pathID(r, "quizId")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		writeError(w, err)
+		return 0, 0, false
 	}
-
-	w.WriteHeader(200)
-	w.Write(b)
+	return userId, quizId, true
 }
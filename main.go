@@ -14,6 +14,11 @@ import (
 // @license.url   http://www.apache.org/licenses/LICENSE-2.0.html
 
 // @host      localhost:8080
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Session token from POST /auth/login, sent as "Bearer <token>"
 func main() {
 	cmd.Execute()
 }
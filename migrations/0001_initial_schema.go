@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The structs below are a snapshot of models/entities.go at the time of this migration.
+// They must not change when the models evolve, later migrations alter the tables instead.
+
+type initialBase struct {
+	ID        uint32 `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type initialUser struct {
+	Base initialBase `gorm:"embedded"`
+	Name string
+}
+
+func (initialUser) TableName() string { return "users" }
+
+type initialQuiz struct {
+	Base initialBase `gorm:"embedded"`
+	Name string
+}
+
+func (initialQuiz) TableName() string { return "quizzes" }
+
+type initialQuestion struct {
+	Base     initialBase `gorm:"embedded"`
+	Question string
+	QuizID   uint32 `gorm:"index"`
+}
+
+func (initialQuestion) TableName() string { return "questions" }
+
+type initialOption struct {
+	Base       initialBase `gorm:"embedded"`
+	QuestionID uint32      `gorm:"index"`
+	Value      string
+	IsCorrect  bool
+}
+
+func (initialOption) TableName() string { return "options" }
+
+type initialProgression struct {
+	Base              initialBase `gorm:"embedded"`
+	UserID            uint32      `gorm:"index"`
+	QuizID            uint32      `gorm:"index"`
+	IsFinished        bool
+	CurrentQuestionID uint32
+	QuestionNumber    int
+}
+
+func (initialProgression) TableName() string { return "progressions" }
+
+type initialScore struct {
+	Base   initialBase `gorm:"embedded"`
+	QuizID uint32      `gorm:"index"`
+	UserID uint32      `gorm:"index"`
+	Score  float32
+}
+
+func (initialScore) TableName() string { return "scores" }
+
+type initialAnswer struct {
+	Base     initialBase `gorm:"embedded"`
+	UserID   uint32      `gorm:"index"`
+	OptionID uint32      `gorm:"index"`
+	QuizID   uint32      `gorm:"index"`
+}
+
+func (initialAnswer) TableName() string { return "answers" }
+
+// initialSchema creates the tables that used to be created by AutoMigrate.
+// Tables that already exist are left untouched so databases created before migrations can adopt them.
+var initialSchema = Migration{
+	Version: 1,
+	Name:    "initial_schema",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx,
+			&initialUser{},
+			&initialQuiz{},
+			&initialQuestion{},
+			&initialProgression{},
+			&initialOption{},
+			&initialScore{},
+			&initialAnswer{},
+		)
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(
+			&initialAnswer{},
+			&initialScore{},
+			&initialOption{},
+			&initialProgression{},
+			&initialQuestion{},
+			&initialQuiz{},
+			&initialUser{},
+		)
+	},
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// credentialsUser adds a password hash to users and makes names unique so they can be used to log in.
+// Names become varchar(255) first since MySQL cannot index text columns.
+type credentialsUser struct {
+	Name         string `gorm:"size:255;uniqueIndex:idx_users_name"`
+	PasswordHash string `gorm:"size:255"`
+}
+
+func (credentialsUser) TableName() string { return "users" }
+
+var userCredentials = Migration{
+	Version: 2,
+	Name:    "user_credentials",
+	Up: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if err := m.AlterColumn(&credentialsUser{}, "Name"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &credentialsUser{}, "PasswordHash"); err != nil {
+			return err
+		}
+		if m.HasIndex(&credentialsUser{}, "idx_users_name") {
+			return nil
+		}
+		return m.CreateIndex(&credentialsUser{}, "idx_users_name")
+	},
+	Down: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if m.HasIndex(&credentialsUser{}, "idx_users_name") {
+			if err := m.DropIndex(&credentialsUser{}, "idx_users_name"); err != nil {
+				return err
+			}
+		}
+		return dropColumns(tx, &credentialsUser{}, "PasswordHash")
+	},
+}
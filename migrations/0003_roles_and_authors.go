@@ -0,0 +1,46 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// rolesUser gives every user a role, existing users become takers.
+// Users from before passwords cannot log in, so operators make the first admin with the admin command instead.
+type rolesUser struct {
+	Role string `gorm:"size:16;not null;default:taker"`
+}
+
+func (rolesUser) TableName() string { return "users" }
+
+// rolesQuiz records the author of a quiz, existing quizzes are left without one and can only be managed by admins
+type rolesQuiz struct {
+	AuthorID uint32 `gorm:"index"`
+}
+
+func (rolesQuiz) TableName() string { return "quizzes" }
+
+var rolesAndAuthors = Migration{
+	Version: 3,
+	Name:    "roles_and_authors",
+	Up: func(tx *gorm.DB) error {
+		if err := addColumns(tx, &rolesUser{}, "Role"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &rolesQuiz{}, "AuthorID"); err != nil {
+			return err
+		}
+		if m := tx.Migrator(); !m.HasIndex(&rolesQuiz{}, "AuthorID") {
+			return m.CreateIndex(&rolesQuiz{}, "AuthorID")
+		}
+		return nil
+	},
+	Down: func(tx *gorm.DB) error {
+		if m := tx.Migrator(); m.HasIndex(&rolesQuiz{}, "AuthorID") {
+			if err := m.DropIndex(&rolesQuiz{}, "AuthorID"); err != nil {
+				return err
+			}
+		}
+		if err := dropColumns(tx, &rolesQuiz{}, "AuthorID"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &rolesUser{}, "Role")
+	},
+}
@@ -0,0 +1,88 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// positionsQuestion and positionsOption add an explicit order to questions and options.
+// Existing rows are numbered in the order they were created, which is the order they were shown in so far.
+type positionsQuestion struct {
+	QuizID   uint32 `gorm:"index:idx_questions_quiz_position,priority:1"`
+	Position int    `gorm:"not null;default:0;index:idx_questions_quiz_position,priority:2"`
+}
+
+func (positionsQuestion) TableName() string { return "questions" }
+
+type positionsOption struct {
+	QuestionID uint32 `gorm:"index:idx_options_question_position,priority:1"`
+	Position   int    `gorm:"not null;default:0;index:idx_options_question_position,priority:2"`
+}
+
+func (positionsOption) TableName() string { return "options" }
+
+var positions = Migration{
+	Version: 4,
+	Name:    "positions",
+	Up: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if err := addColumns(tx, &positionsQuestion{}, "Position"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &positionsOption{}, "Position"); err != nil {
+			return err
+		}
+		if !m.HasIndex(&positionsQuestion{}, "idx_questions_quiz_position") {
+			if err := m.CreateIndex(&positionsQuestion{}, "idx_questions_quiz_position"); err != nil {
+				return err
+			}
+		}
+		if !m.HasIndex(&positionsOption{}, "idx_options_question_position") {
+			if err := m.CreateIndex(&positionsOption{}, "idx_options_question_position"); err != nil {
+				return err
+			}
+		}
+
+		if err := numberRows(tx, "questions", "quiz_id"); err != nil {
+			return err
+		}
+		return numberRows(tx, "options", "question_id")
+	},
+	Down: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if m.HasIndex(&positionsOption{}, "idx_options_question_position") {
+			if err := m.DropIndex(&positionsOption{}, "idx_options_question_position"); err != nil {
+				return err
+			}
+		}
+		if m.HasIndex(&positionsQuestion{}, "idx_questions_quiz_position") {
+			if err := m.DropIndex(&positionsQuestion{}, "idx_questions_quiz_position"); err != nil {
+				return err
+			}
+		}
+		if err := dropColumns(tx, &positionsOption{}, "Position"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &positionsQuestion{}, "Position")
+	},
+}
+
+// numberRows sets the position of every row in table to its index among the rows with the same parent ordered by id
+func numberRows(tx *gorm.DB, table string, parent string) error {
+	var rows []struct {
+		ID     uint32
+		Parent uint32
+	}
+	if err := tx.Table(table).Select("id, " + parent + " AS parent").Order(parent + ", id").Find(&rows).Error; err != nil {
+		return err
+	}
+
+	position := 0
+	for i, row := range rows {
+		if i > 0 && rows[i-1].Parent != row.Parent {
+			position = 0
+		}
+		if err := tx.Table(table).Where("id = ?", row.ID).Update("position", position).Error; err != nil {
+			return err
+		}
+		position++
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// orderQuiz adds the shuffle settings of a quiz
+type orderQuiz struct {
+	ShuffleQuestions bool `gorm:"not null;default:false"`
+	ShuffleOptions   bool `gorm:"not null;default:false"`
+}
+
+func (orderQuiz) TableName() string { return "quizzes" }
+
+// orderProgression stores the seed and the orders a progression gets the questions and options in.
+// Progressions without an order follow the positions of their quiz.
+type orderProgression struct {
+	Seed          int64 `gorm:"not null;default:0"`
+	QuestionOrder string
+	OptionOrder   string
+}
+
+func (orderProgression) TableName() string { return "progressions" }
+
+// orderAnswer and orderScore tie answers and scores to the progression they belong to
+// now that progressions are kept after they are submitted
+type orderAnswer struct {
+	ProgressionID uint32 `gorm:"not null;default:0;index:idx_answers_progression_id"`
+}
+
+func (orderAnswer) TableName() string { return "answers" }
+
+type orderScore struct {
+	ProgressionID uint32 `gorm:"not null;default:0;index:idx_scores_progression_id"`
+}
+
+func (orderScore) TableName() string { return "scores" }
+
+var progressionOrder = Migration{
+	Version: 5,
+	Name:    "progression_order",
+	Up: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if err := addColumns(tx, &orderQuiz{}, "ShuffleQuestions", "ShuffleOptions"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &orderProgression{}, "Seed", "QuestionOrder", "OptionOrder"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &orderAnswer{}, "ProgressionID"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &orderScore{}, "ProgressionID"); err != nil {
+			return err
+		}
+		if !m.HasIndex(&orderAnswer{}, "idx_answers_progression_id") {
+			if err := m.CreateIndex(&orderAnswer{}, "idx_answers_progression_id"); err != nil {
+				return err
+			}
+		}
+		if !m.HasIndex(&orderScore{}, "idx_scores_progression_id") {
+			if err := m.CreateIndex(&orderScore{}, "idx_scores_progression_id"); err != nil {
+				return err
+			}
+		}
+		return linkAnswers(tx)
+	},
+	Down: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if m.HasIndex(&orderScore{}, "idx_scores_progression_id") {
+			if err := m.DropIndex(&orderScore{}, "idx_scores_progression_id"); err != nil {
+				return err
+			}
+		}
+		if m.HasIndex(&orderAnswer{}, "idx_answers_progression_id") {
+			if err := m.DropIndex(&orderAnswer{}, "idx_answers_progression_id"); err != nil {
+				return err
+			}
+		}
+		if err := dropColumns(tx, &orderScore{}, "ProgressionID"); err != nil {
+			return err
+		}
+		if err := dropColumns(tx, &orderAnswer{}, "ProgressionID"); err != nil {
+			return err
+		}
+		if err := dropColumns(tx, &orderProgression{}, "Seed", "QuestionOrder", "OptionOrder"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &orderQuiz{}, "ShuffleQuestions", "ShuffleOptions")
+	},
+}
+
+// linkAnswers ties the answers given since a progression in flight began to that progression.
+// Submitted progressions used to be deleted, so their answers stay unlinked.
+// The newest progressions go first so each one only claims the answers given after it began.
+func linkAnswers(tx *gorm.DB) error {
+	var progressions []struct {
+		ID        uint32
+		UserID    uint32
+		QuizID    uint32
+		CreatedAt time.Time
+	}
+	if err := tx.Table("progressions").Select("id, user_id, quiz_id, created_at").Order("id DESC").Find(&progressions).Error; err != nil {
+		return err
+	}
+
+	for _, p := range progressions {
+		err := tx.Table("answers").
+			Where("user_id = ? AND quiz_id = ? AND progression_id = 0 AND created_at >= ?", p.UserID, p.QuizID, p.CreatedAt).
+			Update("progression_id", p.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// typesQuestion adds the type and scoring rule of a question.
+// Existing questions are single questions, which the scoring rule and penalty do not apply to.
+type typesQuestion struct {
+	Type    string  `gorm:"size:16;not null;default:single"`
+	Scoring string  `gorm:"size:16;not null;default:all_or_nothing"`
+	Penalty float32 `gorm:"not null;default:0"`
+}
+
+func (typesQuestion) TableName() string { return "questions" }
+
+var questionTypes = Migration{
+	Version: 6,
+	Name:    "question_types",
+	Up: func(tx *gorm.DB) error {
+		return addColumns(tx, &typesQuestion{}, "Type", "Scoring", "Penalty")
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropColumns(tx, &typesQuestion{}, "Type", "Scoring", "Penalty")
+	},
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// answerKeyQuestion adds the answer key of text and numeric questions
+type answerKeyQuestion struct {
+	AcceptedAnswers string
+	Matching        string `gorm:"size:16"`
+	CaseSensitive   bool   `gorm:"not null;default:false"`
+	NumericAnswer   *float64
+	Tolerance       float64 `gorm:"not null;default:0"`
+}
+
+func (answerKeyQuestion) TableName() string { return "questions" }
+
+// answerKeyAnswer adds the text or number given to a question along with the question itself,
+// which existing answers take from the option they picked
+type answerKeyAnswer struct {
+	QuestionID uint32 `gorm:"not null;default:0;index:idx_answers_question_id"`
+	Text       string `gorm:"size:1000"`
+	Number     *float64
+}
+
+func (answerKeyAnswer) TableName() string { return "answers" }
+
+var textAndNumeric = Migration{
+	Version: 7,
+	Name:    "text_and_numeric",
+	Up: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if err := addColumns(tx, &answerKeyQuestion{}, "AcceptedAnswers", "Matching", "CaseSensitive", "NumericAnswer", "Tolerance"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &answerKeyAnswer{}, "QuestionID", "Text", "Number"); err != nil {
+			return err
+		}
+		if !m.HasIndex(&answerKeyAnswer{}, "idx_answers_question_id") {
+			if err := m.CreateIndex(&answerKeyAnswer{}, "idx_answers_question_id"); err != nil {
+				return err
+			}
+		}
+		return tx.Exec("UPDATE answers SET question_id = (SELECT options.question_id FROM options WHERE options.id = answers.option_id) " +
+			"WHERE question_id = 0 AND option_id <> 0 AND EXISTS (SELECT 1 FROM options WHERE options.id = answers.option_id)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if m.HasIndex(&answerKeyAnswer{}, "idx_answers_question_id") {
+			if err := m.DropIndex(&answerKeyAnswer{}, "idx_answers_question_id"); err != nil {
+				return err
+			}
+		}
+		if err := dropColumns(tx, &answerKeyAnswer{}, "QuestionID", "Text", "Number"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &answerKeyQuestion{}, "AcceptedAnswers", "Matching", "CaseSensitive", "NumericAnswer", "Tolerance")
+	},
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// orderingOption adds the place of an option in the correct order and the item it matches
+type orderingOption struct {
+	Step int    `gorm:"not null;default:0"`
+	Pair string `gorm:"size:255"`
+}
+
+func (orderingOption) TableName() string { return "options" }
+
+// orderingAnswer adds the place an option was put at when answering an ordering question
+type orderingAnswer struct {
+	Position int `gorm:"not null;default:0"`
+}
+
+func (orderingAnswer) TableName() string { return "answers" }
+
+var orderingAndMatching = Migration{
+	Version: 8,
+	Name:    "ordering_and_matching",
+	Up: func(tx *gorm.DB) error {
+		if err := addColumns(tx, &orderingOption{}, "Step", "Pair"); err != nil {
+			return err
+		}
+		return addColumns(tx, &orderingAnswer{}, "Position")
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := dropColumns(tx, &orderingAnswer{}, "Position"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &orderingOption{}, "Step", "Pair")
+	},
+}
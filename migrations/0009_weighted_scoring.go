@@ -0,0 +1,58 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// pointsQuestion adds what a question is worth, every existing question stays worth 1
+type pointsQuestion struct {
+	Points float32 `gorm:"not null;default:1"`
+}
+
+func (pointsQuestion) TableName() string { return "questions" }
+
+// pointsQuiz adds the penalties and pass threshold of a quiz
+type pointsQuiz struct {
+	WrongPenalty      float32 `gorm:"not null;default:0"`
+	UnansweredPenalty float32 `gorm:"not null;default:0"`
+	PassThreshold     float32 `gorm:"not null;default:0"`
+}
+
+func (pointsQuiz) TableName() string { return "quizzes" }
+
+// pointsScore adds the points behind a score
+type pointsScore struct {
+	RawPoints  float32 `gorm:"not null;default:0"`
+	MaxPoints  float32 `gorm:"not null;default:0"`
+	Percentage float32 `gorm:"not null;default:0"`
+	Passed     bool    `gorm:"not null;default:false"`
+}
+
+func (pointsScore) TableName() string { return "scores" }
+
+var weightedScoring = Migration{
+	Version: 9,
+	Name:    "weighted_scoring",
+	Up: func(tx *gorm.DB) error {
+		if err := addColumns(tx, &pointsQuestion{}, "Points"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &pointsQuiz{}, "WrongPenalty", "UnansweredPenalty", "PassThreshold"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &pointsScore{}, "RawPoints", "MaxPoints", "Percentage", "Passed"); err != nil {
+			return err
+		}
+		// existing scores were averages over questions worth 1 point each and had nothing to pass
+		return tx.Exec("UPDATE scores SET max_points = (SELECT COUNT(*) FROM questions WHERE questions.quiz_id = scores.quiz_id), "+
+			"raw_points = score * (SELECT COUNT(*) FROM questions WHERE questions.quiz_id = scores.quiz_id), "+
+			"percentage = score * 100, passed = ? WHERE max_points = 0", true).Error
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := dropColumns(tx, &pointsScore{}, "RawPoints", "MaxPoints", "Percentage", "Passed"); err != nil {
+			return err
+		}
+		if err := dropColumns(tx, &pointsQuiz{}, "WrongPenalty", "UnansweredPenalty", "PassThreshold"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &pointsQuestion{}, "Points")
+	},
+}
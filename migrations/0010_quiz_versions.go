@@ -0,0 +1,184 @@
+package migrations
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// versionsTable holds the immutable snapshots of quizzes, Snapshot is the quiz with its questions and options as JSON
+type versionsTable struct {
+	Base     initialBase `gorm:"embedded"`
+	QuizID   uint32      `gorm:"uniqueIndex:idx_quiz_versions_quiz_number,priority:1"`
+	Number   uint32      `gorm:"uniqueIndex:idx_quiz_versions_quiz_number,priority:2"`
+	Checksum string      `gorm:"size:64"`
+	Snapshot string
+}
+
+func (versionsTable) TableName() string { return "quiz_versions" }
+
+// versionProgression and versionScore tie progressions and scores to the version of the quiz they were taken on
+type versionProgression struct {
+	VersionID uint32 `gorm:"not null;default:0;index:idx_progressions_version_id"`
+}
+
+func (versionProgression) TableName() string { return "progressions" }
+
+type versionScore struct {
+	VersionID uint32 `gorm:"not null;default:0;index:idx_scores_version_id"`
+}
+
+func (versionScore) TableName() string { return "scores" }
+
+// The structs below read a quiz as it is at the time of this migration and write it in the JSON form of models.Quiz
+
+type versionQuiz struct {
+	ID                uint32            `json:"id"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	UpdatedAt         time.Time         `json:"updatedAt"`
+	Name              string            `json:"name"`
+	AuthorID          uint32            `json:"authorId"`
+	ShuffleQuestions  bool              `json:"shuffleQuestions"`
+	ShuffleOptions    bool              `json:"shuffleOptions"`
+	WrongPenalty      float32           `json:"wrongPenalty"`
+	UnansweredPenalty float32           `json:"unansweredPenalty"`
+	PassThreshold     float32           `json:"passThreshold"`
+	Questions         []versionQuestion `gorm:"-" json:"questions"`
+}
+
+type versionQuestion struct {
+	ID              uint32          `json:"id"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+	Question        string          `json:"question"`
+	QuizID          uint32          `json:"quizId"`
+	Position        int             `json:"position"`
+	Type            string          `json:"type"`
+	Scoring         string          `json:"scoring"`
+	Penalty         float32         `json:"penalty"`
+	Points          float32         `json:"points"`
+	AcceptedAnswers string          `json:"-"`
+	Accepted        json.RawMessage `gorm:"-" json:"acceptedAnswers,omitempty"`
+	Matching        string          `json:"matching,omitempty"`
+	CaseSensitive   bool            `json:"caseSensitive,omitempty"`
+	NumericAnswer   *float64        `json:"numericAnswer,omitempty"`
+	Tolerance       float64         `json:"tolerance,omitempty"`
+	Options         []versionOption `gorm:"-" json:"options"`
+}
+
+type versionOption struct {
+	ID         uint32    `json:"id"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	QuestionID uint32    `json:"questionId"`
+	Position   int       `json:"position"`
+	Value      string    `json:"value"`
+	IsCorrect  bool      `json:"isCorrect"`
+	Step       int       `json:"step"`
+	Pair       string    `json:"pair,omitempty"`
+}
+
+var quizVersions = Migration{
+	Version: 10,
+	Name:    "quiz_versions",
+	Up: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if err := createTables(tx, &versionsTable{}); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &versionProgression{}, "VersionID"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &versionScore{}, "VersionID"); err != nil {
+			return err
+		}
+		if !m.HasIndex(&versionProgression{}, "idx_progressions_version_id") {
+			if err := m.CreateIndex(&versionProgression{}, "idx_progressions_version_id"); err != nil {
+				return err
+			}
+		}
+		if !m.HasIndex(&versionScore{}, "idx_scores_version_id") {
+			if err := m.CreateIndex(&versionScore{}, "idx_scores_version_id"); err != nil {
+				return err
+			}
+		}
+		return versionTakenQuizzes(tx)
+	},
+	Down: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if m.HasIndex(&versionScore{}, "idx_scores_version_id") {
+			if err := m.DropIndex(&versionScore{}, "idx_scores_version_id"); err != nil {
+				return err
+			}
+		}
+		if m.HasIndex(&versionProgression{}, "idx_progressions_version_id") {
+			if err := m.DropIndex(&versionProgression{}, "idx_progressions_version_id"); err != nil {
+				return err
+			}
+		}
+		if err := dropColumns(tx, &versionScore{}, "VersionID"); err != nil {
+			return err
+		}
+		if err := dropColumns(tx, &versionProgression{}, "VersionID"); err != nil {
+			return err
+		}
+		if m.HasTable(&versionsTable{}) {
+			return m.DropTable(&versionsTable{})
+		}
+		return nil
+	},
+}
+
+// versionTakenQuizzes saves the first version of every quiz that has been taken and ties its progressions and scores to it.
+// Taken quizzes could not be edited until now, so they still match what was taken.
+// The checksum is left empty and filled in by the server the next time the quiz is begun.
+func versionTakenQuizzes(tx *gorm.DB) error {
+	var quizzes []versionQuiz
+	err := tx.Table("quizzes").
+		Where("id IN (SELECT quiz_id FROM progressions) OR id IN (SELECT quiz_id FROM scores)").
+		Order("id").Find(&quizzes).Error
+	if err != nil {
+		return err
+	}
+
+	for _, quiz := range quizzes {
+		var count int64
+		if err := tx.Table("quiz_versions").Where("quiz_id = ?", quiz.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		if err := tx.Table("questions").Where("quiz_id = ?", quiz.ID).Order("position, id").Find(&quiz.Questions).Error; err != nil {
+			return err
+		}
+		for i := range quiz.Questions {
+			q := &quiz.Questions[i]
+			if q.AcceptedAnswers != "" {
+				q.Accepted = json.RawMessage(q.AcceptedAnswers)
+			}
+			if err := tx.Table("options").Where("question_id = ?", q.ID).Order("position, id").Find(&q.Options).Error; err != nil {
+				return err
+			}
+		}
+		snapshot, err := json.Marshal(quiz)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		version := versionsTable{Base: initialBase{CreatedAt: now, UpdatedAt: now}, QuizID: quiz.ID, Number: 1, Snapshot: string(snapshot)}
+		if err := tx.Create(&version).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("progressions").Where("quiz_id = ?", quiz.ID).Update("version_id", version.Base.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("scores").Where("quiz_id = ?", quiz.ID).Update("version_id", version.Base.ID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// statusQuiz adds the lifecycle status of a quiz, new quizzes start as drafts
+type statusQuiz struct {
+	Status string `gorm:"size:16;not null;default:draft;index:idx_quizzes_status"`
+}
+
+func (statusQuiz) TableName() string { return "quizzes" }
+
+var quizStatus = Migration{
+	Version: 11,
+	Name:    "quiz_status",
+	Up: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if err := addColumns(tx, &statusQuiz{}, "Status"); err != nil {
+			return err
+		}
+		if !m.HasIndex(&statusQuiz{}, "idx_quizzes_status") {
+			if err := m.CreateIndex(&statusQuiz{}, "idx_quizzes_status"); err != nil {
+				return err
+			}
+		}
+		// every quiz with questions could be begun so far, it stays available to takers
+		return tx.Exec("UPDATE quizzes SET status = ? WHERE id IN (SELECT quiz_id FROM questions)", "published").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		if m := tx.Migrator(); m.HasIndex(&statusQuiz{}, "idx_quizzes_status") {
+			if err := m.DropIndex(&statusQuiz{}, "idx_quizzes_status"); err != nil {
+				return err
+			}
+		}
+		return dropColumns(tx, &statusQuiz{}, "Status")
+	},
+}
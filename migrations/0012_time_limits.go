@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// limitQuiz and limitQuestion add the time limits of quizzes and questions in seconds, existing ones have none
+type limitQuiz struct {
+	TimeLimit uint32 `gorm:"not null;default:0"`
+}
+
+func (limitQuiz) TableName() string { return "quizzes" }
+
+type limitQuestion struct {
+	TimeLimit uint32 `gorm:"not null;default:0"`
+}
+
+func (limitQuestion) TableName() string { return "questions" }
+
+// limitProgression adds when a progression started and the deadlines it has to keep
+type limitProgression struct {
+	StartedAt        time.Time
+	Deadline         *time.Time
+	QuestionDeadline *time.Time
+}
+
+func (limitProgression) TableName() string { return "progressions" }
+
+var timeLimits = Migration{
+	Version: 12,
+	Name:    "time_limits",
+	Up: func(tx *gorm.DB) error {
+		if err := addColumns(tx, &limitQuiz{}, "TimeLimit"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &limitQuestion{}, "TimeLimit"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &limitProgression{}, "StartedAt", "Deadline", "QuestionDeadline"); err != nil {
+			return err
+		}
+		// progressions began when they were created
+		return tx.Exec("UPDATE progressions SET started_at = created_at WHERE started_at IS NULL").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := dropColumns(tx, &limitProgression{}, "StartedAt", "Deadline", "QuestionDeadline"); err != nil {
+			return err
+		}
+		if err := dropColumns(tx, &limitQuestion{}, "TimeLimit"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &limitQuiz{}, "TimeLimit")
+	},
+}
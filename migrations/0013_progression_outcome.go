@@ -0,0 +1,36 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// outcomeProgression adds how a progression was closed, open progressions have none
+type outcomeProgression struct {
+	Outcome string `gorm:"size:16;not null;default:'';index:idx_progressions_outcome"`
+}
+
+func (outcomeProgression) TableName() string { return "progressions" }
+
+var progressionOutcome = Migration{
+	Version: 13,
+	Name:    "progression_outcome",
+	Up: func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if err := addColumns(tx, &outcomeProgression{}, "Outcome"); err != nil {
+			return err
+		}
+		if !m.HasIndex(&outcomeProgression{}, "idx_progressions_outcome") {
+			if err := m.CreateIndex(&outcomeProgression{}, "idx_progressions_outcome"); err != nil {
+				return err
+			}
+		}
+		// every progression with a score has been submitted
+		return tx.Exec("UPDATE progressions SET outcome = ? WHERE id IN (SELECT progression_id FROM scores)", "submitted").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		if m := tx.Migrator(); m.HasIndex(&outcomeProgression{}, "idx_progressions_outcome") {
+			if err := m.DropIndex(&outcomeProgression{}, "idx_progressions_outcome"); err != nil {
+				return err
+			}
+		}
+		return dropColumns(tx, &outcomeProgression{}, "Outcome")
+	},
+}
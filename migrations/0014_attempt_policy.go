@@ -0,0 +1,111 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// policyQuiz adds how often and how frequently a quiz can be taken and which score of a user counts, best by default
+type policyQuiz struct {
+	MaxAttempts uint32 `gorm:"not null;default:0"`
+	Cooldown    uint32 `gorm:"not null;default:0"`
+	ScorePolicy string `gorm:"size:16;not null;default:best"`
+}
+
+func (policyQuiz) TableName() string { return "quizzes" }
+
+// attemptProgression and attemptScore number the attempts of a user on a quiz
+type attemptProgression struct {
+	Attempt uint32 `gorm:"not null;default:0"`
+}
+
+func (attemptProgression) TableName() string { return "progressions" }
+
+// uniqueAttemptProgression keeps a user from beginning the same attempt on a quiz twice
+type uniqueAttemptProgression struct {
+	UserID  uint32 `gorm:"uniqueIndex:idx_progressions_user_quiz_attempt,priority:1"`
+	QuizID  uint32 `gorm:"uniqueIndex:idx_progressions_user_quiz_attempt,priority:2"`
+	Attempt uint32 `gorm:"uniqueIndex:idx_progressions_user_quiz_attempt,priority:3"`
+}
+
+func (uniqueAttemptProgression) TableName() string { return "progressions" }
+
+type attemptScore struct {
+	Attempt uint32 `gorm:"not null;default:0"`
+}
+
+func (attemptScore) TableName() string { return "scores" }
+
+var attemptPolicy = Migration{
+	Version: 14,
+	Name:    "attempt_policy",
+	Up: func(tx *gorm.DB) error {
+		if err := addColumns(tx, &policyQuiz{}, "MaxAttempts", "Cooldown", "ScorePolicy"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &attemptProgression{}, "Attempt"); err != nil {
+			return err
+		}
+		if err := addColumns(tx, &attemptScore{}, "Attempt"); err != nil {
+			return err
+		}
+		if err := numberAttempts(tx); err != nil {
+			return err
+		}
+		if m := tx.Migrator(); !m.HasIndex(&uniqueAttemptProgression{}, "idx_progressions_user_quiz_attempt") {
+			return m.CreateIndex(&uniqueAttemptProgression{}, "idx_progressions_user_quiz_attempt")
+		}
+		return nil
+	},
+	Down: func(tx *gorm.DB) error {
+		if m := tx.Migrator(); m.HasIndex(&uniqueAttemptProgression{}, "idx_progressions_user_quiz_attempt") {
+			if err := m.DropIndex(&uniqueAttemptProgression{}, "idx_progressions_user_quiz_attempt"); err != nil {
+				return err
+			}
+		}
+		if err := dropColumns(tx, &attemptScore{}, "Attempt"); err != nil {
+			return err
+		}
+		if err := dropColumns(tx, &attemptProgression{}, "Attempt"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &policyQuiz{}, "MaxAttempts", "Cooldown", "ScorePolicy")
+	},
+}
+
+// numberAttempts counts the attempts of every user on every quiz in the order they were made.
+// Scores from before progressions were kept come first, the progressions after them pass their number on to their scores.
+func numberAttempts(tx *gorm.DB) error {
+	type attempt struct {
+		ID     uint32
+		UserID uint32
+		QuizID uint32
+	}
+	type key struct{ user, quiz uint32 }
+	counts := make(map[key]uint32)
+
+	var scores []attempt
+	if err := tx.Table("scores").Where("progression_id = 0").Order("id").Find(&scores).Error; err != nil {
+		return err
+	}
+	for _, s := range scores {
+		k := key{s.UserID, s.QuizID}
+		counts[k]++
+		if err := tx.Table("scores").Where("id = ?", s.ID).Update("attempt", counts[k]).Error; err != nil {
+			return err
+		}
+	}
+
+	var progressions []attempt
+	if err := tx.Table("progressions").Order("id").Find(&progressions).Error; err != nil {
+		return err
+	}
+	for _, p := range progressions {
+		k := key{p.UserID, p.QuizID}
+		counts[k]++
+		if err := tx.Table("progressions").Where("id = ?", p.ID).Update("attempt", counts[k]).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("scores").Where("progression_id = ?", p.ID).Update("attempt", counts[k]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
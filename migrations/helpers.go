@@ -0,0 +1,45 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// createTables creates the tables of the given snapshot structs unless they already exist
+func createTables(tx *gorm.DB, tables ...any) error {
+	m := tx.Migrator()
+	for _, t := range tables {
+		if m.HasTable(t) {
+			continue
+		}
+		if err := m.CreateTable(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumns adds the given fields of a snapshot struct to its table unless they already exist
+func addColumns(tx *gorm.DB, model any, fields ...string) error {
+	m := tx.Migrator()
+	for _, f := range fields {
+		if m.HasColumn(model, f) {
+			continue
+		}
+		if err := m.AddColumn(model, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropColumns removes the given fields of a snapshot struct from its table when they exist
+func dropColumns(tx *gorm.DB, model any, fields ...string) error {
+	m := tx.Migrator()
+	for _, f := range fields {
+		if !m.HasColumn(model, f) {
+			continue
+		}
+		if err := m.DropColumn(model, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
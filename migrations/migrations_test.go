@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lghtr35/quiz-maker/database"
+	"gorm.io/gorm"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := database.Open(database.DriverSQLite, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// tables lists the tables of the database without the ones sqlite keeps for itself
+func tables(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+	names, err := db.Migrator().GetTables()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names = slices.DeleteFunc(names, func(name string) bool { return strings.HasPrefix(name, "sqlite_") })
+	slices.Sort(names)
+	return names
+}
+
+func TestUpAndDown(t *testing.T) {
+	db := openTestDB(t)
+
+	applied, err := Up(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != len(All()) {
+		t.Fatalf("applied %d migrations, want %d", len(applied), len(All()))
+	}
+	for _, table := range []string{"users", "quizzes", "questions", "options", "answers", "progressions", "scores"} {
+		if !db.Migrator().HasTable(table) {
+			t.Errorf("table %s is missing after migrating up", table)
+		}
+	}
+	if applied, err = Up(db); err != nil || len(applied) != 0 {
+		t.Fatalf("migrating up again applied %d migrations with error %v", len(applied), err)
+	}
+
+	rolledBack, err := Down(db, len(All()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rolledBack) != len(All()) {
+		t.Fatalf("rolled back %d migrations, want %d", len(rolledBack), len(All()))
+	}
+	if got := tables(t, db); !slices.Equal(got, []string{"schema_migrations"}) {
+		t.Errorf("tables %v are left after migrating down", got)
+	}
+	pending, err := Pending(db)
+	if err != nil || len(pending) != len(All()) {
+		t.Fatalf("%d migrations are pending after migrating down with error %v", len(pending), err)
+	}
+
+	if _, err = Up(db); err != nil {
+		t.Fatalf("migrating up after migrating down: %v", err)
+	}
+}
+
+// TestEveryMigrationRollsBack rolls back every migration on its own and applies it again on top of the ones before it
+func TestEveryMigrationRollsBack(t *testing.T) {
+	db := openTestDB(t)
+	if _, err := Up(db); err != nil {
+		t.Fatal(err)
+	}
+	schema := tables(t, db)
+
+	migrations := All()
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		rolledBack, err := Down(db, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rolledBack) != 1 || rolledBack[0].Version != m.Version {
+			t.Fatalf("rolled back %d migrations, want only %04d_%s", len(rolledBack), m.Version, m.Name)
+		}
+
+		// the migration and every one after it apply again, then everything from this one on is rolled back once more
+		applied, err := Up(db)
+		if err != nil {
+			t.Fatalf("reapplying %04d_%s: %v", m.Version, m.Name, err)
+		}
+		if got := tables(t, db); !slices.Equal(got, schema) {
+			t.Fatalf("got tables %v after reapplying %04d_%s, want %v", got, m.Version, m.Name, schema)
+		}
+		if _, err = Down(db, len(applied)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := tables(t, db); !slices.Equal(got, []string{"schema_migrations"}) {
+		t.Errorf("tables %v are left after rolling back every migration", got)
+	}
+}
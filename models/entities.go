@@ -10,56 +10,260 @@ type Base struct {
 
 type Score struct {
 	Base
-	QuizID uint32  `json:"quizId"`
-	UserID uint32  `json:"userId"`
-	Score  float32 `json:"score"`
+	QuizID uint32 `json:"quizId"`
+	UserID uint32 `json:"userId"`
+	// ProgressionID is the attempt the score was calculated from, it is 0 for scores from before progressions were kept
+	ProgressionID uint32 `gorm:"index" json:"progressionId"`
+	// VersionID is the version of the quiz the score was calculated on, it is 0 for scores from before quizzes had versions
+	VersionID uint32 `gorm:"index" json:"versionId"`
+	// Attempt counts the attempts of the user on the quiz from 1 up, it is 0 for a score averaged over several attempts
+	Attempt uint32 `gorm:"not null;default:0" json:"attempt"`
+	// Score is Percentage as a fraction between 0 and 1
+	Score float32 `json:"score"`
+	// RawPoints are the points earned after penalties, MaxPoints what every question is worth together
+	RawPoints float32 `gorm:"not null;default:0" json:"rawPoints"`
+	MaxPoints float32 `gorm:"not null;default:0" json:"maxPoints"`
+	// Percentage is RawPoints out of MaxPoints, it never drops below 0
+	Percentage float32 `gorm:"not null;default:0" json:"percentage"`
+	// Passed tells whether Percentage reached the pass threshold of the quiz
+	Passed bool `gorm:"not null;default:false" json:"passed"`
 }
 
 type Progression struct {
 	Base
-	UserID            uint32 `json:"userId"`
-	QuizID            uint32 `json:"quizId"`
+	UserID uint32 `gorm:"uniqueIndex:idx_progressions_user_quiz_attempt,priority:1" json:"userId"`
+	QuizID uint32 `gorm:"uniqueIndex:idx_progressions_user_quiz_attempt,priority:2" json:"quizId"`
+	// Attempt counts the attempts of the user on the quiz from 1 up, attempts that expired count as well.
+	// A user cannot begin the same attempt twice, so concurrent begins cannot take more attempts than the quiz allows.
+	Attempt uint32 `gorm:"not null;default:0;uniqueIndex:idx_progressions_user_quiz_attempt,priority:3" json:"attempt"`
+	// VersionID is the version of the quiz this attempt takes, it is 0 for attempts from before quizzes had versions
+	VersionID         uint32 `gorm:"index" json:"versionId"`
 	IsFinished        bool   `json:"isFinished"`
 	CurrentQuestionID uint32 `json:"currentQuestionId"`
 	QuestionNumber    int    `json:"questionNumber"`
+	// StartedAt is when the attempt began, Deadline when it is submitted on its own when the quiz has a time limit
+	StartedAt time.Time  `json:"startedAt"`
+	Deadline  *time.Time `json:"deadline,omitempty"`
+	// QuestionDeadline is when the current question times out when it has a time limit, it never lies past Deadline
+	QuestionDeadline *time.Time `json:"questionDeadline,omitempty"`
+	// Outcome is one of ProgressionOutcomes once the attempt is closed, it is empty while the attempt is open
+	Outcome string `gorm:"size:16;not null;default:'';index" json:"outcome,omitempty"`
+	// Seed is the random seed the question and option orders of this attempt were shuffled with
+	Seed int64 `json:"seed"`
+	// QuestionOrder holds the question ids in the order this attempt gets them
+	QuestionOrder []uint32 `gorm:"serializer:json" json:"questionOrder"`
+	// OptionOrder holds the option ids of every question in the order this attempt sees them when options are shuffled
+	OptionOrder map[uint32][]uint32 `gorm:"serializer:json" json:"optionOrder,omitempty"`
 }
 
+// Answer is an option picked for a question, or the text or number given to a text or numeric question.
+// Ordering questions get an answer for every option with the Position it was put at,
+// matching questions an answer for every paired option with the item it was paired with in Text.
 type Answer struct {
 	Base
-	UserID   uint32 `json:"userId"`
-	OptionID uint32 `json:"optionId"`
-	QuizID   uint32 `json:"quizId"`
+	UserID        uint32   `json:"userId"`
+	OptionID      uint32   `json:"optionId"`
+	QuizID        uint32   `json:"quizId"`
+	ProgressionID uint32   `gorm:"index" json:"progressionId"`
+	QuestionID    uint32   `gorm:"index" json:"questionId"`
+	Text          string   `gorm:"size:1000" json:"text,omitempty"`
+	Number        *float64 `json:"number,omitempty"`
+	Position      int      `json:"position,omitempty"`
 }
 
 type OptionBase struct {
 	Base
-	QuestionID uint32   `json:"questionId"`
-	Value      string   `json:"value"`
-	Answers    []Answer `json:"answers"`
+	QuestionID uint32 `gorm:"index:idx_options_question_position,priority:1" json:"questionId"`
+	// Position orders the options of a question, options are shown from the lowest position up
+	Position int      `gorm:"index:idx_options_question_position,priority:2" json:"position"`
+	Value    string   `json:"value"`
+	Answers  []Answer `json:"answers"`
 }
 
 type Option struct {
 	OptionBase
 	IsCorrect bool `json:"isCorrect"`
+	// Step is the place of the option in the correct order of an ordering question
+	Step int `gorm:"not null;default:0" json:"step"`
+	// Pair is the item the option has to be matched with in a matching question
+	Pair string `gorm:"size:255" json:"pair,omitempty"`
 }
 
 type Question struct {
 	Base
-	Question     string        `json:"question"`
-	QuizID       uint32        `json:"quizId"`
-	Options      []Option      `json:"options"`
-	Progressions []Progression `gorm:"foreignKey:CurrentQuestionID" json:"progressions"`
+	Question string `json:"question"`
+	QuizID   uint32 `gorm:"index:idx_questions_quiz_position,priority:1" json:"quizId"`
+	// Position orders the questions of a quiz, takers get them from the lowest position up
+	Position int `gorm:"index:idx_questions_quiz_position,priority:2" json:"position"`
+	// Type is one of QuestionTypes and decides how the question is answered
+	Type string `gorm:"size:16;not null;default:single" json:"type"`
+	// Scoring is one of ScoringRules and decides how much credit an answer to a multiple, ordering or matching question earns
+	Scoring string `gorm:"size:16;not null;default:all_or_nothing" json:"scoring"`
+	// Penalty is the share of the credit lost under partial scoring when every wrong option is picked,
+	// each wrong pick costs an equal part of it
+	Penalty float32 `gorm:"not null;default:0" json:"penalty"`
+	// Points is what the question is worth when it earns full credit
+	Points float32 `gorm:"not null;default:1" json:"points"`
+	// TimeLimit is how many seconds the question can be answered in once it is shown, 0 means no limit
+	TimeLimit uint32 `gorm:"not null;default:0" json:"timeLimit"`
+	// AcceptedAnswers are the answers a text question accepts, compared as Matching says
+	AcceptedAnswers []string `gorm:"serializer:json" json:"acceptedAnswers,omitempty"`
+	// Matching is one of TextMatchings
+	Matching string `gorm:"size:16" json:"matching,omitempty"`
+	// CaseSensitive makes either matching tell upper and lower case apart
+	CaseSensitive bool `json:"caseSensitive,omitempty"`
+	// NumericAnswer is the number a numeric question expects, answers within Tolerance of it are accepted
+	NumericAnswer *float64 `json:"numericAnswer,omitempty"`
+	Tolerance     float64  `json:"tolerance,omitempty"`
+	Options       []Option `json:"options"`
 }
 
 type Quiz struct {
 	Base
-	Name      string     `json:"name"`
-	Questions []Question `json:"questions"`
-	Answers   []Answer   `json:"answers"`
+	Name string `json:"name"`
+	// AuthorID is the user who created the quiz, only they and admins can change it
+	AuthorID uint32 `gorm:"index" json:"authorId"`
+	// Status is one of QuizStatuses, only published quizzes can be begun
+	Status string `gorm:"size:16;not null;default:draft;index" json:"status"`
+	// ShuffleQuestions and ShuffleOptions give every attempt its own random order of questions and options
+	ShuffleQuestions bool `json:"shuffleQuestions"`
+	ShuffleOptions   bool `json:"shuffleOptions"`
+	// WrongPenalty is the share of its points a question costs when it is answered without earning any credit
+	WrongPenalty float32 `gorm:"not null;default:0" json:"wrongPenalty"`
+	// UnansweredPenalty is the share of its points a question costs when it is left unanswered, by default it just earns nothing
+	UnansweredPenalty float32 `gorm:"not null;default:0" json:"unansweredPenalty"`
+	// TimeLimit is how many seconds an attempt can take before it is submitted on its own, 0 means no limit
+	TimeLimit uint32 `gorm:"not null;default:0" json:"timeLimit"`
+	// MaxAttempts is how many times a user can begin the quiz, 0 means no limit
+	MaxAttempts uint32 `gorm:"not null;default:0" json:"maxAttempts"`
+	// Cooldown is how many seconds a user waits after an attempt finished before the next one can begin
+	Cooldown uint32 `gorm:"not null;default:0" json:"cooldown"`
+	// ScorePolicy is one of ScorePolicies and decides which score of a user counts when they took the quiz more than once
+	ScorePolicy string `gorm:"size:16;not null;default:best" json:"scorePolicy"`
+	// PassThreshold is the percentage a score needs to pass, every score passes when it is 0
+	PassThreshold float32    `gorm:"not null;default:0" json:"passThreshold"`
+	Questions     []Question `json:"questions"`
+	Answers       []Answer   `json:"answers"`
+}
+
+// QuizVersion is an immutable snapshot of a quiz with its questions, options and answer key.
+// Progressions and scores point at the version they were taken on, so editing the quiz never changes them.
+type QuizVersion struct {
+	Base
+	QuizID uint32 `gorm:"uniqueIndex:idx_quiz_versions_quiz_number,priority:1" json:"quizId"`
+	// Number counts the versions of a quiz from 1 up
+	Number uint32 `gorm:"uniqueIndex:idx_quiz_versions_quiz_number,priority:2" json:"number"`
+	// Checksum identifies the content of the snapshot so a quiz begun again without edits keeps its version
+	Checksum string `gorm:"size:64" json:"-"`
+	// Snapshot is left out when versions are listed
+	Snapshot *Quiz `gorm:"serializer:json" json:"snapshot,omitempty"`
 }
 
 type User struct {
 	Base
-	Name    string   `json:"name"`
-	Answers []Answer `json:"answer"`
+	Name string `gorm:"size:255;uniqueIndex:idx_users_name" json:"name"`
+	// PasswordHash is the bcrypt hash of the user's password, it is never serialized
+	PasswordHash string   `gorm:"size:255" json:"-"`
+	Role         string   `gorm:"size:16;not null;default:taker" json:"role"`
+	Answers      []Answer `json:"answer"`
 }
+
+const (
+	// RoleAdmin can manage every user and quiz
+	RoleAdmin = "admin"
+	// RoleAuthor can create quizzes and manage the ones they created
+	RoleAuthor = "author"
+	// RoleTaker can only take quizzes and see their own results
+	RoleTaker = "taker"
+)
+
+// Roles lists every role a user can have
+var Roles = []string{RoleAdmin, RoleAuthor, RoleTaker}
+
+const (
+	// QuizStatusDraft quizzes are still being written and cannot be taken
+	QuizStatusDraft = "draft"
+	// QuizStatusPublished quizzes can be taken, takers get the version saved when the quiz was last published
+	QuizStatusPublished = "published"
+	// QuizStatusArchived quizzes cannot be taken anymore, attempts already begun can still be finished
+	QuizStatusArchived = "archived"
+)
+
+// QuizStatuses lists every status a quiz can have
+var QuizStatuses = []string{QuizStatusDraft, QuizStatusPublished, QuizStatusArchived}
+
+const (
+	// ProgressionOutcomeSubmitted attempts were submitted by their taker
+	ProgressionOutcomeSubmitted = "submitted"
+	// ProgressionOutcomeTimedOut attempts ran out of time and were submitted with the answers given until then
+	ProgressionOutcomeTimedOut = "timed_out"
+	// ProgressionOutcomeExpired attempts were abandoned and closed without a score
+	ProgressionOutcomeExpired = "expired"
+)
+
+// ProgressionOutcomes lists every outcome a closed progression can have
+var ProgressionOutcomes = []string{ProgressionOutcomeSubmitted, ProgressionOutcomeTimedOut, ProgressionOutcomeExpired}
+
+const (
+	// ScorePolicyBest counts the highest score of a user
+	ScorePolicyBest = "best"
+	// ScorePolicyLatest counts the score of the latest attempt
+	ScorePolicyLatest = "latest"
+	// ScorePolicyAverage counts the average of every score
+	ScorePolicyAverage = "average"
+	// ScorePolicyFirst counts the score of the first attempt
+	ScorePolicyFirst = "first"
+)
+
+// ScorePolicies lists every policy a quiz can count the scores of its takers by
+var ScorePolicies = []string{ScorePolicyBest, ScorePolicyLatest, ScorePolicyAverage, ScorePolicyFirst}
+
+const (
+	// QuestionTypeSingle questions are answered with exactly one option
+	QuestionTypeSingle = "single"
+	// QuestionTypeMultiple questions are answered with a set of options
+	QuestionTypeMultiple = "multiple"
+	// QuestionTypeText questions are answered with a short text
+	QuestionTypeText = "text"
+	// QuestionTypeNumeric questions are answered with a number
+	QuestionTypeNumeric = "numeric"
+	// QuestionTypeOrdering questions are answered by putting their options in order
+	QuestionTypeOrdering = "ordering"
+	// QuestionTypeMatching questions are answered by pairing their options with the items they match
+	QuestionTypeMatching = "matching"
+)
+
+// QuestionTypes lists every type a question can have
+var QuestionTypes = []string{QuestionTypeSingle, QuestionTypeMultiple, QuestionTypeText, QuestionTypeNumeric, QuestionTypeOrdering, QuestionTypeMatching}
+
+// HasOptions reports whether questions of the type are answered with their options
+func HasOptions(questionType string) bool {
+	return questionType != QuestionTypeText && questionType != QuestionTypeNumeric
+}
+
+// IsChoice reports whether questions of the type are answered by picking options, which are then correct or not
+func IsChoice(questionType string) bool {
+	return questionType == QuestionTypeSingle || questionType == QuestionTypeMultiple
+}
+
+const (
+	// MatchingExact accepts a text equal to an accepted answer ignoring surrounding and repeated whitespace
+	MatchingExact = "exact"
+	// MatchingRegex accepts a text fully matched by one of the accepted answers as regular expressions
+	MatchingRegex = "regex"
+)
+
+// TextMatchings lists every way the answer to a text question can be compared to the accepted answers
+var TextMatchings = []string{MatchingExact, MatchingRegex}
+
+const (
+	// ScoringAllOrNothing credits an answer only when it picks exactly the correct options,
+	// or puts every option of an ordering or matching question in its right place or pair
+	ScoringAllOrNothing = "all_or_nothing"
+	// ScoringPartial credits every correct pick and takes the penalty off for wrong ones,
+	// ordering and matching questions credit every option in its right place or pair
+	ScoringPartial = "partial"
+)
+
+// ScoringRules lists every rule a question can be scored by
+var ScoringRules = []string{ScoringAllOrNothing, ScoringPartial}
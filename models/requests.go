@@ -1,57 +1,392 @@
 package models
 
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lghtr35/quiz-maker/validation"
+)
+
 type PaginationRequest struct {
 	Page uint32 `json:"page"`
-	Size uint32 `json:"size"`
+	Size uint32 `json:"size" binding:"max=100"`
 }
 
 type ReadUsersRequest struct {
 	PaginationRequest
 	IDList *[]uint32 `json:"idList"`
 	Name   *string   `json:"name"`
+	Role   *string   `json:"role"`
+	// Cursor is the nextCursor of a previous page, when given it is used instead of page
+	Cursor *string `json:"cursor"`
 }
 
 type CreateUserRequest struct {
-	Name string `json:"name" binding:"required"`
+	Name string `json:"name" binding:"required,max=255"`
+	// Password is limited to 72 bytes by bcrypt
+	Password string `json:"password" binding:"required,min=8,max=72"`
 }
 
 type UpdateUserRequest struct {
-	ID   uint32  `json:"id" binding:"required"`
-	Name *string `json:"name"`
+	ID       uint32  `json:"id" binding:"required"`
+	Name     *string `json:"name" binding:"max=255"`
+	Password *string `json:"password" binding:"min=8,max=72"`
+	// Role can only be changed by admins
+	Role *string `json:"role"`
+}
+
+func (r UpdateUserRequest) Validate() validation.Errors {
+	if r.Role != nil && !slices.Contains(Roles, *r.Role) {
+		return validation.Errors{{Field: "role", Message: "must be one of " + strings.Join(Roles, ", ")}}
+	}
+	return nil
+}
+
+// QuizSortFields are the fields quizzes can be sorted by, prefixed with - for descending order
+var QuizSortFields = []string{"id", "name", "createdAt"}
+
+type LoginRequest struct {
+	Name     string `json:"name" binding:"required,max=255"`
+	Password string `json:"password" binding:"required,max=72"`
 }
 
 type ReadQuizRequest struct {
 	PaginationRequest
 	IDList *[]uint32 `json:"idList"`
 	Name   *string   `json:"name"`
+	Status *string   `json:"status"`
+	Sort   *string   `json:"sort"`
+}
+
+func (r ReadQuizRequest) Validate() validation.Errors {
+	var errs validation.Errors
+	if r.Status != nil && *r.Status != "" && !slices.Contains(QuizStatuses, *r.Status) {
+		errs = append(errs, validation.FieldError{Field: "status", Message: "must be one of " + strings.Join(QuizStatuses, ", ")})
+	}
+	if r.Sort == nil || *r.Sort == "" {
+		return errs
+	}
+	field := strings.TrimPrefix(*r.Sort, "-")
+	if !slices.Contains(QuizSortFields, field) {
+		errs = append(errs, validation.FieldError{Field: "sort", Message: "must be one of " + strings.Join(QuizSortFields, ", ") + " optionally prefixed with -"})
+	}
+	return errs
 }
 
 type CreateQuizRequest struct {
-	Name      string                  `json:"name" binding:"required"`
-	Questions []CreateQuestionRequest `json:"questions" bindind:"required"`
+	Name             string `json:"name" binding:"required,max=255"`
+	ShuffleQuestions bool   `json:"shuffleQuestions"`
+	ShuffleOptions   bool   `json:"shuffleOptions"`
+	// WrongPenalty and UnansweredPenalty are shares of the points of a question, PassThreshold a percentage
+	// and TimeLimit is in seconds with 0 meaning no limit
+	WrongPenalty      float32                 `json:"wrongPenalty" binding:"min=0,max=1"`
+	UnansweredPenalty float32                 `json:"unansweredPenalty" binding:"min=0,max=1"`
+	PassThreshold     float32                 `json:"passThreshold" binding:"min=0,max=100"`
+	TimeLimit         uint32                  `json:"timeLimit" binding:"max=86400"`
+	Questions         []CreateQuestionRequest `json:"questions" binding:"required,max=200"`
+	// MaxAttempts of 0 means no limit, Cooldown is in seconds and ScorePolicy one of ScorePolicies, best by default
+	MaxAttempts uint32 `json:"maxAttempts" binding:"max=1000"`
+	Cooldown    uint32 `json:"cooldown" binding:"max=31536000"`
+	ScorePolicy string `json:"scorePolicy"`
 }
+
+func (r CreateQuizRequest) Validate() validation.Errors {
+	return validateScorePolicy(&r.ScorePolicy)
+}
+
 type CreateQuestionRequest struct {
-	Question string                 `json:"question" binding:"required"`
-	Options  *[]CreateOptionRequest `json:"options" `
+	Question string `json:"question" binding:"required,max=1000"`
+	// Type is one of QuestionTypes, single by default
+	Type string `json:"type"`
+	// Scoring is one of ScoringRules, all_or_nothing by default
+	Scoring string `json:"scoring"`
+	// Penalty only applies to partial scoring, 1 by default so picking every option earns nothing
+	Penalty *float32 `json:"penalty" binding:"min=0,max=1"`
+	// Points is what the question is worth, 1 by default. TimeLimit is in seconds, 0 means no limit
+	Points    *float32               `json:"points" binding:"max=1000"`
+	TimeLimit uint32                 `json:"timeLimit" binding:"max=86400"`
+	Options   *[]CreateOptionRequest `json:"options" binding:"max=50"`
+	// AcceptedAnswers, Matching and CaseSensitive only apply to text questions, Matching is exact by default
+	AcceptedAnswers []string `json:"acceptedAnswers" binding:"max=50"`
+	Matching        string   `json:"matching"`
+	CaseSensitive   bool     `json:"caseSensitive"`
+	// NumericAnswer and Tolerance only apply to numeric questions
+	NumericAnswer *float64 `json:"numericAnswer"`
+	Tolerance     float64  `json:"tolerance" binding:"min=0"`
 }
+
+// CreateOptionRequest is an option of a question. The options of an ordering question are given in their correct order
+// and the options of a matching question each with the pair they match.
 type CreateOptionRequest struct {
-	Value     string `json:"value"`
+	Value     string `json:"value" binding:"required,max=255"`
 	IsCorrect bool   `json:"isCorrect"`
+	Pair      string `json:"pair" binding:"max=255"`
+}
+
+// Validate checks the type and scoring of the question along with what its type needs to be scored.
+// Options can be left out and added one by one later on.
+func (r CreateQuestionRequest) Validate() validation.Errors {
+	errs := validateQuestionType(&r.Type, &r.Scoring, &r.Matching)
+	errs = append(errs, validatePoints(r.Points)...)
+	var pairs []string
+	if r.Options != nil {
+		pairs = make([]string, len(*r.Options))
+		for i, o := range *r.Options {
+			pairs[i] = o.Pair
+		}
+	}
+	errs = append(errs, validateAnswerKey(r.Type, pairs, r.AcceptedAnswers, r.Matching, r.NumericAnswer)...)
+	if r.Options == nil || !HasOptions(r.Type) {
+		return errs
+	}
+	if len(*r.Options) == 0 {
+		return append(errs, validation.FieldError{Field: "options", Message: "must not be empty when given"})
+	}
+	if r.Type != "" && !IsChoice(r.Type) {
+		if len(*r.Options) < 2 {
+			errs = append(errs, validation.FieldError{Field: "options", Message: "must contain at least 2 items for " + r.Type + " questions"})
+		}
+		return errs
+	}
+	for _, o := range *r.Options {
+		if o.IsCorrect {
+			return errs
+		}
+	}
+	return append(errs, validation.FieldError{Field: "options", Message: "must have at least one correct option"})
+}
+
+type UpdateQuestionRequest struct {
+	Question        *string   `json:"question" binding:"max=1000"`
+	Type            *string   `json:"type"`
+	Scoring         *string   `json:"scoring"`
+	Penalty         *float32  `json:"penalty" binding:"min=0,max=1"`
+	Points          *float32  `json:"points" binding:"max=1000"`
+	TimeLimit       *uint32   `json:"timeLimit" binding:"max=86400"`
+	AcceptedAnswers *[]string `json:"acceptedAnswers" binding:"max=50"`
+	Matching        *string   `json:"matching"`
+	CaseSensitive   *bool     `json:"caseSensitive"`
+	NumericAnswer   *float64  `json:"numericAnswer"`
+	Tolerance       *float64  `json:"tolerance" binding:"min=0"`
+}
+
+func (r UpdateQuestionRequest) Validate() validation.Errors {
+	return append(validateQuestionType(r.Type, r.Scoring, r.Matching), validatePoints(r.Points)...)
+}
+
+// validatePoints checks that a question is worth something when its points are given
+func validatePoints(points *float32) validation.Errors {
+	if points != nil && *points <= 0 {
+		return validation.Errors{{Field: "points", Message: "must be greater than 0"}}
+	}
+	return nil
+}
+
+// ValidateQuestion checks that the question has what its type needs to be answered and scored
+func ValidateQuestion(q *Question) validation.Errors {
+	pairs := make([]string, len(q.Options))
+	for i, o := range q.Options {
+		pairs[i] = o.Pair
+	}
+	return validateAnswerKey(q.Type, pairs, q.AcceptedAnswers, q.Matching, q.NumericAnswer)
+}
+
+// ValidatePublish checks that every question of the quiz can be answered and scored before it is published:
+// single and multiple questions need a correct option, ordering and matching questions at least 2 options
+// and text and numeric questions their answer key
+func ValidatePublish(quiz *Quiz) validation.Errors {
+	var errs validation.Errors
+	for i := range quiz.Questions {
+		q := &quiz.Questions[i]
+		questionErrs := ValidateQuestion(q)
+		switch {
+		case IsChoice(q.Type) && !slices.ContainsFunc(q.Options, func(o Option) bool { return o.IsCorrect }):
+			questionErrs = append(questionErrs, validation.FieldError{Field: "options", Message: "must have at least one correct option"})
+		case HasOptions(q.Type) && !IsChoice(q.Type) && len(q.Options) < 2:
+			questionErrs = append(questionErrs, validation.FieldError{Field: "options", Message: "must contain at least 2 items for " + q.Type + " questions"})
+		}
+		for _, e := range questionErrs {
+			e.Field = fmt.Sprintf("questions[%d].%s", i, e.Field)
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+// validateQuestionType checks that the type, scoring rule and matching are known when they are given
+func validateQuestionType(questionType *string, scoring *string, matching *string) validation.Errors {
+	var errs validation.Errors
+	if questionType != nil && *questionType != "" && !slices.Contains(QuestionTypes, *questionType) {
+		errs = append(errs, validation.FieldError{Field: "type", Message: "must be one of " + strings.Join(QuestionTypes, ", ")})
+	}
+	if scoring != nil && *scoring != "" && !slices.Contains(ScoringRules, *scoring) {
+		errs = append(errs, validation.FieldError{Field: "scoring", Message: "must be one of " + strings.Join(ScoringRules, ", ")})
+	}
+	if matching != nil && *matching != "" && !slices.Contains(TextMatchings, *matching) {
+		errs = append(errs, validation.FieldError{Field: "matching", Message: "must be one of " + strings.Join(TextMatchings, ", ")})
+	}
+	return errs
+}
+
+// validateAnswerKey checks that text questions have accepted answers, numeric questions have a number,
+// that neither of them has options and that every option of a matching question has a pair.
+// pairs holds the pair of every option.
+func validateAnswerKey(questionType string, pairs []string, accepted []string, matching string, numeric *float64) validation.Errors {
+	var errs validation.Errors
+	if !HasOptions(questionType) && len(pairs) > 0 {
+		errs = append(errs, validation.FieldError{Field: "options", Message: "must be left out for " + questionType + " questions"})
+	}
+
+	switch questionType {
+	case QuestionTypeMatching:
+		for i, p := range pairs {
+			if strings.TrimSpace(p) == "" {
+				errs = append(errs, validation.FieldError{Field: fmt.Sprintf("options[%d].pair", i), Message: "is required for matching questions"})
+			}
+		}
+	case QuestionTypeText:
+		if len(accepted) == 0 {
+			errs = append(errs, validation.FieldError{Field: "acceptedAnswers", Message: "is required for text questions"})
+		}
+		for i, a := range accepted {
+			field := fmt.Sprintf("acceptedAnswers[%d]", i)
+			if strings.TrimSpace(a) == "" {
+				errs = append(errs, validation.FieldError{Field: field, Message: "is required"})
+				continue
+			}
+			if matching == MatchingRegex {
+				if _, err := regexp.Compile(a); err != nil {
+					errs = append(errs, validation.FieldError{Field: field, Message: "is not a valid regular expression"})
+				}
+			}
+		}
+	case QuestionTypeNumeric:
+		if numeric == nil {
+			errs = append(errs, validation.FieldError{Field: "numericAnswer", Message: "is required for numeric questions"})
+		}
+	}
+	return errs
+}
+
+type UpdateOptionRequest struct {
+	Value     *string `json:"value" binding:"max=255"`
+	IsCorrect *bool   `json:"isCorrect"`
+	Step      *int    `json:"step" binding:"min=0"`
+	Pair      *string `json:"pair" binding:"max=255"`
+}
+
+// ReorderRequest lists every question of a quiz or every option of a question in their new order
+type ReorderRequest struct {
+	IDs []uint32 `json:"ids" binding:"required"`
 }
 
 type UpdateQuizRequest struct {
 	ID   uint32  `json:"id" binding:"required"`
-	Name *string `json:"name"`
+	Name *string `json:"name" binding:"max=255"`
+	// ShuffleQuestions, ShuffleOptions, WrongPenalty, UnansweredPenalty, PassThreshold and TimeLimit
+	// only apply to attempts on versions published afterwards
+	ShuffleQuestions  *bool    `json:"shuffleQuestions"`
+	ShuffleOptions    *bool    `json:"shuffleOptions"`
+	WrongPenalty      *float32 `json:"wrongPenalty" binding:"min=0,max=1"`
+	UnansweredPenalty *float32 `json:"unansweredPenalty" binding:"min=0,max=1"`
+	PassThreshold     *float32 `json:"passThreshold" binding:"min=0,max=100"`
+	TimeLimit         *uint32  `json:"timeLimit" binding:"max=86400"`
+	// MaxAttempts, Cooldown and ScorePolicy apply right away, to attempts and scores from before as well
+	MaxAttempts *uint32 `json:"maxAttempts" binding:"max=1000"`
+	Cooldown    *uint32 `json:"cooldown" binding:"max=31536000"`
+	ScorePolicy *string `json:"scorePolicy"`
 }
 
+func (r UpdateQuizRequest) Validate() validation.Errors {
+	return validateScorePolicy(r.ScorePolicy)
+}
+
+// validateScorePolicy checks that the score policy is known when it is given
+func validateScorePolicy(policy *string) validation.Errors {
+	if policy != nil && *policy != "" && !slices.Contains(ScorePolicies, *policy) {
+		return validation.Errors{{Field: "scorePolicy", Message: "must be one of " + strings.Join(ScorePolicies, ", ")}}
+	}
+	return nil
+}
+
+// BeginQuizRequest starts a quiz for the authenticated user
 type BeginQuizRequest struct {
 	QuizID uint32 `json:"quizId" binding:"required"`
-	UserID uint32 `json:"userId" binding:"required"`
 }
 
+// ReadQuestionRequest optionally names the progression the question is read for,
+// the question is then shown as in the version of the quiz that progression takes with its options ordered the way it sees them
+type ReadQuestionRequest struct {
+	ProgressionID uint32 `json:"progressionId"`
+}
+
+// ReadResultRequest optionally narrows scores, rankings and analyses down to a single version of the quiz by its number.
+// Attempt picks the score and analysis of a single attempt instead of the one the score policy of the quiz counts.
+type ReadResultRequest struct {
+	Version uint32 `json:"version"`
+	Attempt uint32 `json:"attempt"`
+}
+
+// AnswerQuizQuestionRequest answers the current question of a progression. Exactly one of the answers is given:
+// optionId for single questions, the set of optionIds for multiple questions, text for text questions, number for numeric questions,
+// every option id in order for ordering questions and the pairs of options and items for matching questions.
 type AnswerQuizQuestionRequest struct {
-	OptionID      uint32 `json:"optionId" binding:"required"`
-	ProgressionID uint32 `json:"progressionId" binding:"required"`
+	OptionID      uint32       `json:"optionId"`
+	OptionIDs     []uint32     `json:"optionIds" binding:"max=50"`
+	Text          *string      `json:"text" binding:"max=1000"`
+	Number        *float64     `json:"number"`
+	Order         []uint32     `json:"order" binding:"max=50"`
+	Pairs         []AnswerPair `json:"pairs" binding:"max=50"`
+	ProgressionID uint32       `json:"progressionId" binding:"required"`
+}
+
+// AnswerPair pairs an option of a matching question with an item
+type AnswerPair struct {
+	OptionID uint32 `json:"optionId" binding:"required"`
+	Pair     string `json:"pair" binding:"required,max=255"`
+}
+
+func (r AnswerQuizQuestionRequest) Validate() validation.Errors {
+	given := 0
+	for _, ok := range []bool{r.OptionID != 0, len(r.OptionIDs) > 0, r.Text != nil, r.Number != nil, len(r.Order) > 0, len(r.Pairs) > 0} {
+		if ok {
+			given++
+		}
+	}
+	if given == 0 {
+		return validation.Errors{{Field: "optionId", Message: "one of optionId, optionIds, text, number, order or pairs is required"}}
+	}
+	if given > 1 {
+		return validation.Errors{{Field: "optionId", Message: "only one of optionId, optionIds, text, number, order or pairs can be given"}}
+	}
+	paired := make(map[uint32]bool, len(r.Pairs))
+	for _, p := range r.Pairs {
+		if paired[p.OptionID] {
+			return validation.Errors{{Field: "pairs", Message: "must pair every option at most once"}}
+		}
+		paired[p.OptionID] = true
+	}
+	seen := make(map[uint32]bool, len(r.OptionIDs))
+	for _, id := range r.OptionIDs {
+		if id == 0 || seen[id] {
+			return validation.Errors{{Field: "optionIds", Message: "must be distinct option ids"}}
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// ChosenOptions returns the ids of every option the answer picks, which are none for text and number answers
+func (r AnswerQuizQuestionRequest) ChosenOptions() []uint32 {
+	if len(r.OptionIDs) > 0 {
+		return r.OptionIDs
+	}
+	if r.OptionID != 0 {
+		return []uint32{r.OptionID}
+	}
+	return nil
 }
 
 type FinalizeQuizRequest struct {
@@ -1,42 +1,267 @@
 package models
 
+import (
+	"slices"
+	"time"
+)
+
 type PaginationResponse struct {
-	Page    uint32 `json:"page"`
-	Size    uint32 `json:"size"`
-	Content []any  `json:"content"`
+	// Page is 0 when the page was requested with a cursor
+	Page       uint32 `json:"page"`
+	Size       uint32 `json:"size"`
+	Total      int64  `json:"total"`
+	TotalPages uint32 `json:"totalPages"`
+	Content    []any  `json:"content"`
+	// Next and Prev link to the neighbouring pages, they are left out on the first and last pages
+	Next *string `json:"next,omitempty"`
+	Prev *string `json:"prev,omitempty"`
+	// NextCursor continues the listing after the last item of this page when set
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+type LoginResponse struct {
+	// Token is sent back as "Authorization: Bearer <token>" until it expires
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	User      User      `json:"user"`
 }
 
-type QuestionWithOptionsResponse struct {
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// QuizView is a quiz as shown to takers. It leaves out which options are correct and the answers of other users,
+// only the author of the quiz and admins get the full Quiz.
+type QuizView struct {
+	Base
+	Name             string `json:"name"`
+	AuthorID         uint32 `json:"authorId"`
+	Status           string `json:"status"`
+	ShuffleQuestions bool   `json:"shuffleQuestions"`
+	ShuffleOptions   bool   `json:"shuffleOptions"`
+	// WrongPenalty, UnansweredPenalty and PassThreshold tell takers how they are scored
+	WrongPenalty      float32        `json:"wrongPenalty"`
+	UnansweredPenalty float32        `json:"unansweredPenalty"`
+	PassThreshold     float32        `json:"passThreshold"`
+	TimeLimit         uint32         `json:"timeLimit"`
+	MaxAttempts       uint32         `json:"maxAttempts"`
+	Cooldown          uint32         `json:"cooldown"`
+	ScorePolicy       string         `json:"scorePolicy"`
+	Questions         []QuestionView `json:"questions"`
+}
+
+type QuestionView struct {
 	Base
 	Question string       `json:"question"`
-	Answers  []OptionBase `json:"options"`
 	QuizID   uint32       `json:"quizId"`
+	Position int          `json:"position"`
+	Type     string       `json:"type"`
+	Scoring  string       `json:"scoring"`
+	Penalty  float32      `json:"penalty"`
+	Points   float32      `json:"points"`
+	Options  []OptionView `json:"options"`
+	// TimeLimit is how many seconds the question can be answered in, 0 means no limit
+	TimeLimit uint32 `json:"timeLimit"`
+	// Pairs are the items the options of a matching question are paired with, sorted so they do not give away which option they belong to
+	Pairs []string `json:"pairs,omitempty"`
+}
+
+type OptionView struct {
+	Base
+	QuestionID uint32 `json:"questionId"`
+	Position   int    `json:"position"`
+	Value      string `json:"value"`
+}
+
+func NewQuizView(quiz *Quiz) QuizView {
+	view := QuizView{
+		Base:              quiz.Base,
+		Name:              quiz.Name,
+		AuthorID:          quiz.AuthorID,
+		Status:            quiz.Status,
+		ShuffleQuestions:  quiz.ShuffleQuestions,
+		ShuffleOptions:    quiz.ShuffleOptions,
+		WrongPenalty:      quiz.WrongPenalty,
+		UnansweredPenalty: quiz.UnansweredPenalty,
+		PassThreshold:     quiz.PassThreshold,
+		TimeLimit:         quiz.TimeLimit,
+		MaxAttempts:       quiz.MaxAttempts,
+		Cooldown:          quiz.Cooldown,
+		ScorePolicy:       quiz.ScorePolicy,
+		Questions:         make([]QuestionView, len(quiz.Questions)),
+	}
+	for i := range quiz.Questions {
+		view.Questions[i] = NewQuestionView(&quiz.Questions[i])
+	}
+	return view
+}
+
+// QuizVersionView is a version of a quiz as shown to takers, its snapshot leaves out the answer key
+type QuizVersionView struct {
+	Base
+	QuizID   uint32   `json:"quizId"`
+	Number   uint32   `json:"number"`
+	Snapshot QuizView `json:"snapshot"`
+}
+
+func NewQuizVersionView(version *QuizVersion) QuizVersionView {
+	return QuizVersionView{
+		Base:     version.Base,
+		QuizID:   version.QuizID,
+		Number:   version.Number,
+		Snapshot: NewQuizView(version.Snapshot),
+	}
+}
+
+func NewQuestionView(question *Question) QuestionView {
+	view := QuestionView{
+		Base:      question.Base,
+		Question:  question.Question,
+		QuizID:    question.QuizID,
+		Position:  question.Position,
+		Type:      question.Type,
+		Scoring:   question.Scoring,
+		Penalty:   question.Penalty,
+		Points:    question.Points,
+		Options:   make([]OptionView, len(question.Options)),
+		TimeLimit: question.TimeLimit,
+	}
+	for i, o := range question.Options {
+		view.Options[i] = OptionView{
+			Base:       o.Base,
+			QuestionID: o.QuestionID,
+			Position:   o.Position,
+			Value:      o.Value,
+		}
+		if question.Type == QuestionTypeMatching && !slices.Contains(view.Pairs, o.Pair) {
+			view.Pairs = append(view.Pairs, o.Pair)
+		}
+	}
+	slices.Sort(view.Pairs)
+	return view
 }
 
+// BeginQuizResponse carries the new progression and its first question in the order the progression sees it
 type BeginQuizResponse struct {
-	Progression Progression `json:"progression"`
+	Progression     Progression   `json:"progression"`
+	CurrentQuestion *QuestionView `json:"currentQuestion,omitempty"`
+	TimeRemaining
 }
 
+// AnswerQuizQuestionResponse carries the progression and its next question, which is left out once every question is answered
 type AnswerQuizQuestionResponse struct {
-	Progression Progression `json:"progression"`
+	Progression     Progression   `json:"progression"`
+	CurrentQuestion *QuestionView `json:"currentQuestion,omitempty"`
+	// TimedOut tells that the answer came after the question or the quiz ran out of time and was not saved
+	TimedOut bool `json:"timedOut,omitempty"`
+	// Score is the score of the progression when it was submitted because the quiz ran out of time
+	Score *Score `json:"score,omitempty"`
+	TimeRemaining
+}
+
+// TimeRemaining tells a taker how many seconds are left of a timed progression,
+// each is left out when there is no time limit or the progression is finished
+type TimeRemaining struct {
+	RemainingSeconds         *int64 `json:"remainingSeconds,omitempty"`
+	QuestionRemainingSeconds *int64 `json:"questionRemainingSeconds,omitempty"`
+}
+
+func NewTimeRemaining(progression *Progression, now time.Time) TimeRemaining {
+	if progression.IsFinished {
+		return TimeRemaining{}
+	}
+	return TimeRemaining{
+		RemainingSeconds:         secondsUntil(progression.Deadline, now),
+		QuestionRemainingSeconds: secondsUntil(progression.QuestionDeadline, now),
+	}
+}
+
+// secondsUntil returns the seconds left until the deadline rounded up so it only reaches 0 once the time is up,
+// nil when there is no deadline
+func secondsUntil(deadline *time.Time, now time.Time) *int64 {
+	if deadline == nil {
+		return nil
+	}
+	seconds := max(int64((deadline.Sub(now)+time.Second-1)/time.Second), 0)
+	return &seconds
 }
 
 type FinalizeQuizResponse struct {
 	Score Score `json:"score"`
 }
 
+// ReadUserRankingByScoreResponse leaves the answers of the user out, the analysis of the score shows them
 type ReadUserRankingByScoreResponse struct {
-	Rank         uint32   `json:"rank"`
-	Percent      float32  `json:"percent"`
-	Message      string   `json:"message"`
-	Score        Score    `json:"userScore"`
-	GivenAnswers []Option `json:"givenAnswers"`
+	Rank    uint32  `json:"rank"`
+	Percent float32 `json:"percent"`
+	Message string  `json:"message"`
+	Score   Score   `json:"userScore"`
 }
 
+// ReadUserScoreAnalysis lays the quiz out in the order the user took it in when the progression of the score is known.
+// Score is the attempt that is analysed and CountedScore the one the score policy of the quiz counts.
 type ReadUserScoreAnalysis struct {
-	User           User     `json:"user"`
-	Quiz           Quiz     `json:"quiz"`
-	Score          Score    `json:"score"`
-	UserAnswers    []Option `json:"userAnswers"`
-	CorrectAnswers []Option `json:"correctAnswers"`
+	User User `json:"user"`
+	Quiz Quiz `json:"quiz"`
+	// Version is the number of the version of the quiz the score was calculated on, Quiz is laid out as in that version
+	Version        uint32       `json:"version,omitempty"`
+	Progression    *Progression `json:"progression,omitempty"`
+	Score          Score        `json:"score"`
+	CountedScore   Score        `json:"countedScore"`
+	UserAnswers    []Option     `json:"userAnswers"`
+	CorrectAnswers []Option     `json:"correctAnswers"`
+	// Results holds the credit the answers earned on every question of the quiz
+	Results []QuestionResult `json:"results"`
+}
+
+// ScoreAnalysisView is an analysis as shown to takers, it leaves out the answer key of the quiz
+// and whether the options they chose were correct
+type ScoreAnalysisView struct {
+	User         User         `json:"user"`
+	Quiz         QuizView     `json:"quiz"`
+	Version      uint32       `json:"version,omitempty"`
+	Progression  *Progression `json:"progression,omitempty"`
+	Score        Score        `json:"score"`
+	CountedScore Score        `json:"countedScore"`
+	UserAnswers  []OptionView `json:"userAnswers"`
+	// Results holds the credit the answers earned on every question of the quiz
+	Results []QuestionResult `json:"results"`
+}
+
+func NewScoreAnalysisView(analysis *ReadUserScoreAnalysis) ScoreAnalysisView {
+	view := ScoreAnalysisView{
+		User:         analysis.User,
+		Quiz:         NewQuizView(&analysis.Quiz),
+		Version:      analysis.Version,
+		Progression:  analysis.Progression,
+		Score:        analysis.Score,
+		CountedScore: analysis.CountedScore,
+		UserAnswers:  make([]OptionView, len(analysis.UserAnswers)),
+		Results:      analysis.Results,
+	}
+	for i, o := range analysis.UserAnswers {
+		view.UserAnswers[i] = OptionView{
+			Base:       o.Base,
+			QuestionID: o.QuestionID,
+			Position:   o.Position,
+			Value:      o.Value,
+		}
+	}
+	return view
+}
+
+// QuestionResult is the credit between 0 and 1 the answers to a question earned
+// and the points that credit is worth after the penalties of the quiz
+type QuestionResult struct {
+	QuestionID uint32  `json:"questionId"`
+	Credit     float32 `json:"credit"`
+	Answered   bool    `json:"answered"`
+	Points     float32 `json:"points"`
+	MaxPoints  float32 `json:"maxPoints"`
 }
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/store"
+)
+
+// nextAttempt returns the number of the attempt the user begins on the quiz at now,
+// failing when the user has no attempts left or the cooldown since their last attempt finished has not passed yet
+func nextAttempt(ctx context.Context, s store.Store, userID uint32, quiz *models.Quiz, now time.Time) (uint32, error) {
+	var attempts uint32
+	latest, err := s.Progressions().LatestForUserAndQuiz(ctx, userID, quiz.ID)
+	switch {
+	case err == nil:
+		attempts = latest.Attempt
+	case errors.Is(err, store.ErrNotFound):
+		// users who only took the quiz before progressions were kept just have their scores
+		latest = nil
+		scores, err := s.Scores().ListForUserAndQuiz(ctx, userID, quiz.ID, 0)
+		if err != nil {
+			return 0, err
+		}
+		attempts = uint32(len(scores))
+	default:
+		return 0, err
+	}
+
+	if quiz.MaxAttempts > 0 && attempts >= quiz.MaxAttempts {
+		return 0, noAttemptsLeft(quiz)
+	}
+	// a progression is last updated when it is closed, so the cooldown runs from when the attempt finished,
+	// or from its last answer while it is still open
+	if latest != nil && quiz.Cooldown > 0 {
+		if next := latest.UpdatedAt.Add(seconds(quiz.Cooldown)); now.Before(next) {
+			return 0, ErrAttemptCooldown.WithDetails(fmt.Sprintf("the next attempt can begin at %s", next.UTC().Format(time.RFC3339)))
+		}
+	}
+	return attempts + 1, nil
+}
+
+// noAttemptsLeft is the error for a user who took every attempt the quiz allows
+func noAttemptsLeft(quiz *models.Quiz) error {
+	return ErrNoAttemptsLeft.WithDetails(fmt.Sprintf("the quiz can be taken %d times", quiz.MaxAttempts))
+}
+
+// countedScore returns the score the score policy of the quiz counts out of the scores of a user in the order of their attempts.
+// The average is a score of its own without an id or attempt, it passes when its percentage reaches the pass threshold.
+func countedScore(quiz *models.Quiz, scores []models.Score) models.Score {
+	switch quiz.ScorePolicy {
+	case models.ScorePolicyFirst:
+		return scores[0]
+	case models.ScorePolicyLatest:
+		return scores[len(scores)-1]
+	case models.ScorePolicyAverage:
+		if len(scores) == 1 {
+			return scores[0]
+		}
+		average := models.Score{QuizID: scores[0].QuizID, UserID: scores[0].UserID}
+		for _, sc := range scores {
+			average.RawPoints += sc.RawPoints
+			average.MaxPoints += sc.MaxPoints
+			average.Percentage += sc.Percentage
+		}
+		n := float32(len(scores))
+		average.RawPoints /= n
+		average.MaxPoints /= n
+		average.Percentage /= n
+		average.Score = average.Percentage / 100
+		average.Passed = average.Percentage >= quiz.PassThreshold
+		return average
+	default:
+		// the earliest of equally high scores counts
+		best := scores[0]
+		for _, sc := range scores[1:] {
+			if sc.Percentage > best.Percentage {
+				best = sc
+			}
+		}
+		return best
+	}
+}
+
+// analysedScore returns the score of the attempt an analysis shows when no attempt is asked for,
+// which is the counted one or the latest attempt when the counted score is an average
+func analysedScore(quiz *models.Quiz, scores []models.Score) models.Score {
+	if quiz.ScorePolicy == models.ScorePolicyAverage {
+		return scores[len(scores)-1]
+	}
+	return countedScore(quiz, scores)
+}
+
+// scoreOfAttempt returns the score of the given attempt
+func scoreOfAttempt(scores []models.Score, attempt uint32) (models.Score, error) {
+	for _, sc := range scores {
+		if sc.Attempt == attempt {
+			return sc, nil
+		}
+	}
+	return models.Score{}, ErrScoreNotFound
+}
+
+// countedScores returns the counted score of every user out of scores ordered by user and attempt, from the highest to the lowest
+func countedScores(quiz *models.Quiz, scores []models.Score) []models.Score {
+	var counted []models.Score
+	for start := 0; start < len(scores); {
+		end := start + 1
+		for end < len(scores) && scores[end].UserID == scores[start].UserID {
+			end++
+		}
+		counted = append(counted, countedScore(quiz, scores[start:end]))
+		start = end
+	}
+	slices.SortStableFunc(counted, func(a, b models.Score) int {
+		return cmp.Compare(b.Percentage, a.Percentage)
+	})
+	return counted
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+func TestNextAttempt(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	const user, other = 1, 2
+
+	// attempt is an earlier attempt of the user, which is open while it has not finished
+	type attempt struct{ began, finished time.Time }
+	tests := []struct {
+		name     string
+		quiz     models.Quiz
+		attempts []attempt
+		// legacy is how many scores the user has from before progressions were kept
+		legacy  int
+		want    uint32
+		wantErr error
+	}{
+		{name: "first attempt", quiz: models.Quiz{MaxAttempts: 1}, want: 1},
+		{name: "unlimited", attempts: []attempt{{began: now.Add(-time.Hour)}, {began: now.Add(-time.Minute)}}, want: 3},
+		{name: "limit reached", quiz: models.Quiz{MaxAttempts: 2}, attempts: []attempt{{began: now.Add(-time.Hour)}, {began: now.Add(-time.Minute)}}, wantErr: ErrNoAttemptsLeft},
+		{name: "legacy scores count", quiz: models.Quiz{MaxAttempts: 2}, legacy: 2, wantErr: ErrNoAttemptsLeft},
+		{name: "after legacy scores", legacy: 2, want: 3},
+		{name: "cooldown running", quiz: models.Quiz{Cooldown: 600}, attempts: []attempt{{began: now.Add(-6 * time.Minute), finished: now.Add(-5 * time.Minute)}}, wantErr: ErrAttemptCooldown},
+		{name: "cooldown passed", quiz: models.Quiz{Cooldown: 600}, attempts: []attempt{{began: now.Add(-11 * time.Minute), finished: now.Add(-10 * time.Minute)}}, want: 2},
+		// the attempt began longer ago than the cooldown but took most of it
+		{name: "cooldown after a long attempt", quiz: models.Quiz{Cooldown: 600}, attempts: []attempt{{began: now.Add(-20 * time.Minute), finished: now.Add(-5 * time.Minute)}}, wantErr: ErrAttemptCooldown},
+		{name: "cooldown of an open attempt", quiz: models.Quiz{Cooldown: 600}, attempts: []attempt{{began: now.Add(-20 * time.Minute)}}, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMemoryStore()
+			quiz := tt.quiz
+			quiz.ID = 3
+			for i, a := range tt.attempts {
+				p := models.Progression{UserID: user, QuizID: quiz.ID, Attempt: uint32(i + 1), StartedAt: a.began}
+				p.UpdatedAt = a.began
+				if !a.finished.IsZero() {
+					p.IsFinished, p.Outcome, p.UpdatedAt = true, models.ProgressionOutcomeSubmitted, a.finished
+				}
+				if err := s.Progressions().Create(ctx, &p); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for range tt.legacy {
+				if err := s.Scores().Create(ctx, &models.Score{UserID: user, QuizID: quiz.ID}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// attempts of other users and on other quizzes do not count
+			for _, p := range []models.Progression{{UserID: other, QuizID: quiz.ID, Attempt: 1}, {UserID: user, QuizID: quiz.ID + 1, Attempt: 1}} {
+				if err := s.Progressions().Create(ctx, &p); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := nextAttempt(ctx, s, user, &quiz, now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got attempt %d and error %v, want %v", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got attempt %d and error %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountedScore(t *testing.T) {
+	scores := []models.Score{
+		{Attempt: 1, Percentage: 40, RawPoints: 2, MaxPoints: 5},
+		{Attempt: 2, Percentage: 80, RawPoints: 4, MaxPoints: 5},
+		{Attempt: 3, Percentage: 80, RawPoints: 4, MaxPoints: 5},
+		{Attempt: 4, Percentage: 60, RawPoints: 3, MaxPoints: 5},
+	}
+	tests := []struct {
+		policy     string
+		attempt    uint32
+		percentage float32
+	}{
+		{models.ScorePolicyBest, 2, 80},
+		{models.ScorePolicyFirst, 1, 40},
+		{models.ScorePolicyLatest, 4, 60},
+		{models.ScorePolicyAverage, 0, 65},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			quiz := &models.Quiz{ScorePolicy: tt.policy, PassThreshold: 65}
+			got := countedScore(quiz, scores)
+			if got.Attempt != tt.attempt || got.Percentage != tt.percentage {
+				t.Errorf("got attempt %d with %v%%, want attempt %d with %v%%", got.Attempt, got.Percentage, tt.attempt, tt.percentage)
+			}
+		})
+	}
+
+	average := countedScore(&models.Quiz{ScorePolicy: models.ScorePolicyAverage, PassThreshold: 65}, scores)
+	if average.RawPoints != 3.25 || average.MaxPoints != 5 || average.Score != 0.65 || !average.Passed {
+		t.Errorf("got average %+v, want 3.25 of 5 points passing at 65%%", average)
+	}
+	// a single score is its own average
+	if single := countedScore(&models.Quiz{ScorePolicy: models.ScorePolicyAverage}, scores[1:2]); single.Attempt != 2 {
+		t.Errorf("average of a single score: got attempt %d, want 2", single.Attempt)
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lghtr35/quiz-maker/auth"
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/store"
+	"github.com/lghtr35/quiz-maker/validation"
+)
+
+// AuthService logs users in with their credentials and resolves the user behind a session token
+type AuthService struct {
+	store  store.Store
+	signer *auth.Signer
+}
+
+func NewAuthService(s store.Store, signer *auth.Signer) *AuthService {
+	return &AuthService{store: s, signer: signer}
+}
+
+// Login checks the name and password of a user and issues a session token for them
+func (s *AuthService) Login(ctx context.Context, request models.LoginRequest) (*models.LoginResponse, error) {
+	user, err := s.store.Users().GetByName(ctx, request.Name)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = auth.CheckPassword(user.PasswordHash, request.Password); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := s.signer.Issue(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.LoginResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		User:      *user,
+	}, nil
+}
+
+// Authenticate returns the user a session token was issued for.
+// Tokens of users deleted since are rejected like invalid ones.
+func (s *AuthService) Authenticate(ctx context.Context, token string) (*models.User, error) {
+	claims, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.store.Users().Find(ctx, claims.UserID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// hashPassword hashes a password given in a request, passwords bcrypt cannot hash are reported as invalid fields
+func hashPassword(field string, password string) (string, error) {
+	hash, err := auth.HashPassword(password)
+	if errors.Is(err, auth.ErrPasswordTooLong) {
+		return "", ErrValidation.WithDetails(validation.Errors{{Field: field, Message: "must be at most 72 bytes long"}})
+	}
+	return hash, err
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/auth"
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+func TestLoginAndAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore()
+	users := NewUserService(s)
+	authService := NewAuthService(s, auth.NewSigner([]byte("secret"), time.Hour))
+
+	user, err := users.CreateUser(ctx, models.CreateUserRequest{Name: "ada", Password: "correct horse"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = users.CreateUser(ctx, models.CreateUserRequest{Name: "ada", Password: "another one"}); !errors.Is(err, ErrUserNameTaken) {
+		t.Fatalf("registering a taken name: got %v, want %v", err, ErrUserNameTaken)
+	}
+
+	for _, request := range []models.LoginRequest{{Name: "ada", Password: "wrong horse"}, {Name: "grace", Password: "correct horse"}} {
+		if _, err = authService.Login(ctx, request); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("logging in as %q: got %v, want %v", request.Name, err, ErrInvalidCredentials)
+		}
+	}
+	login, err := authService.Login(ctx, models.LoginRequest{Name: "ada", Password: "correct horse"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caller, err := authService.Authenticate(ctx, login.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caller.ID != user.ID {
+		t.Fatalf("token authenticates user %d, want %d", caller.ID, user.ID)
+	}
+	if _, err = authService.Authenticate(ctx, login.Token+"x"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("authenticating a tampered token: got %v, want %v", err, ErrInvalidToken)
+	}
+	if err = users.DeleteUser(ctx, user, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = authService.Authenticate(ctx, login.Token); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("authenticating a deleted user: got %v, want %v", err, ErrInvalidToken)
+	}
+}
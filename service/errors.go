@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/lghtr35/quiz-maker/store"
+)
+
+// Kind classifies an Error so transports can map it to their own status codes
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalid
+	KindNotFound
+	KindConflict
+	KindUnprocessable
+	KindUnavailable
+	KindUnauthenticated
+	KindForbidden
+)
+
+// Error is a failure with a stable code clients can rely on
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports errors with the same code as equal so errors carrying details still match their sentinel
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithDetails returns a copy of the error carrying the given details
+func (e *Error) WithDetails(details any) *Error {
+	c := *e
+	c.Details = details
+	return &c
+}
+
+var (
+	ErrInternal     = &Error{Kind: KindInternal, Code: "internal_error", Message: "internal server error"}
+	ErrInvalidBody  = &Error{Kind: KindInvalid, Code: "invalid_body", Message: "request body is not valid JSON"}
+	ErrInvalidQuery = &Error{Kind: KindInvalid, Code: "invalid_query", Message: "query parameters are malformed"}
+	ErrInvalidID    = &Error{Kind: KindInvalid, Code: "invalid_id", Message: "id in path must be a positive integer"}
+	ErrConflict     = &Error{Kind: KindConflict, Code: "conflict", Message: "record conflicts with an existing one"}
+	ErrValidation   = &Error{Kind: KindUnprocessable, Code: "validation_failed", Message: "request has invalid fields"}
+	ErrNotReady     = &Error{Kind: KindUnavailable, Code: "not_ready", Message: "server is not ready to accept traffic"}
+
+	ErrUnauthenticated     = &Error{Kind: KindUnauthenticated, Code: "unauthenticated", Message: "a valid bearer token is required"}
+	ErrInvalidToken        = &Error{Kind: KindUnauthenticated, Code: "invalid_token", Message: "bearer token is invalid or expired"}
+	ErrInvalidCredentials  = &Error{Kind: KindUnauthenticated, Code: "invalid_credentials", Message: "name or password is wrong"}
+	ErrForbidden           = &Error{Kind: KindForbidden, Code: "forbidden", Message: "you are not allowed to do this"}
+	ErrProgressionNotOwned = &Error{Kind: KindForbidden, Code: "progression_not_owned", Message: "progression belongs to another user"}
+
+	ErrUserNotFound        = &Error{Kind: KindNotFound, Code: "user_not_found", Message: "user not found"}
+	ErrQuizNotFound        = &Error{Kind: KindNotFound, Code: "quiz_not_found", Message: "quiz not found"}
+	ErrQuestionNotFound    = &Error{Kind: KindNotFound, Code: "question_not_found", Message: "question not found"}
+	ErrProgressionNotFound = &Error{Kind: KindNotFound, Code: "progression_not_found", Message: "progression not found"}
+	ErrScoreNotFound       = &Error{Kind: KindNotFound, Code: "score_not_found", Message: "score of this quiz has not been found"}
+	ErrOptionNotFound      = &Error{Kind: KindNotFound, Code: "option_not_found", Message: "option not found"}
+	ErrVersionNotFound     = &Error{Kind: KindNotFound, Code: "version_not_found", Message: "version of this quiz not found"}
+
+	ErrUserNameTaken          = &Error{Kind: KindConflict, Code: "user_name_taken", Message: "a user with this name already exists"}
+	ErrQuizHasNoQuestions     = &Error{Kind: KindConflict, Code: "quiz_has_no_questions", Message: "quiz does not have any questions"}
+	ErrQuizNotPublished       = &Error{Kind: KindConflict, Code: "quiz_not_published", Message: "quiz is not published"}
+	ErrNoAttemptsLeft         = &Error{Kind: KindConflict, Code: "no_attempts_left", Message: "no attempts are left on this quiz"}
+	ErrAttemptCooldown        = &Error{Kind: KindConflict, Code: "attempt_cooldown", Message: "the next attempt cannot begin yet"}
+	ErrQuizFinished           = &Error{Kind: KindConflict, Code: "progression_finished", Message: "quiz is already finished"}
+	ErrProgressionSubmitted   = &Error{Kind: KindConflict, Code: "progression_submitted", Message: "progression has already been submitted"}
+	ErrProgressionExpired     = &Error{Kind: KindConflict, Code: "progression_expired", Message: "progression expired after going unanswered for too long"}
+	ErrQuestionNotInQuiz      = &Error{Kind: KindConflict, Code: "question_not_in_quiz", Message: "question does not belong to this quiz"}
+	ErrOptionNotInQuestion    = &Error{Kind: KindUnprocessable, Code: "option_not_in_question", Message: "chosen option does not belong to this question"}
+	ErrSingleOptionQuestion   = &Error{Kind: KindUnprocessable, Code: "single_option_question", Message: "this question is answered with exactly one option"}
+	ErrAnswerTypeMismatch     = &Error{Kind: KindUnprocessable, Code: "answer_type_mismatch", Message: "answer does not fit the type of this question"}
+	ErrQuestionTakesNoOptions = &Error{Kind: KindUnprocessable, Code: "question_takes_no_options", Message: "text and numeric questions have no options"}
+)
+
+// translate replaces store errors with the service error describing them, notFound is used for missing records
+func translate(err error, notFound *Error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, store.ErrNotFound):
+		return notFound
+	case errors.Is(err, store.ErrConflict):
+		return ErrConflict
+	default:
+		return err
+	}
+}
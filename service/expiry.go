@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/store"
+)
+
+// expiryBatch is how many stale progressions a single sweep closes at most, the rest are left to the next one
+const expiryBatch = 100
+
+// ExpiryService closes progressions their takers never submitted
+type ExpiryService struct {
+	store      store.Store
+	inactivity time.Duration
+}
+
+// NewExpiryService expires progressions without an answer for longer than inactivity, 0 leaves inactive ones open
+func NewExpiryService(s store.Store, inactivity time.Duration) *ExpiryService {
+	return &ExpiryService{store: s, inactivity: inactivity}
+}
+
+// Expired counts the progressions a sweep closed by their outcome
+type Expired struct {
+	Submitted int
+	TimedOut  int
+	Expired   int
+}
+
+// Sweep closes the open progressions that are stale at now.
+// Progressions with every question answered are submitted on behalf of their takers,
+// progressions past their deadline are submitted with the answers given until then and time out,
+// inactive ones without a deadline are expired without a score so they stop counting as taken.
+func (s *ExpiryService) Sweep(ctx context.Context, now time.Time) (Expired, error) {
+	var expired Expired
+	var inactiveSince time.Time
+	if s.inactivity > 0 {
+		inactiveSince = now.Add(-s.inactivity)
+	}
+	progressions, err := s.store.Progressions().ListStale(ctx, now, inactiveSince, expiryBatch)
+	if err != nil {
+		return expired, err
+	}
+
+	// a progression that cannot be closed does not hold up the others
+	var errs []error
+	for _, p := range progressions {
+		var outcome string
+		err := s.store.Transaction(ctx, func(tx store.Store) error {
+			var err error
+			outcome, err = expire(ctx, tx, p.ID, now)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("progression %d: %w", p.ID, err))
+			continue
+		}
+		switch outcome {
+		case models.ProgressionOutcomeSubmitted:
+			expired.Submitted++
+		case models.ProgressionOutcomeTimedOut:
+			expired.TimedOut++
+		case models.ProgressionOutcomeExpired:
+			expired.Expired++
+		}
+	}
+	return expired, errors.Join(errs...)
+}
+
+// expire closes the progression and returns its outcome, it returns no outcome when the progression was closed meanwhile
+func expire(ctx context.Context, s store.Store, id uint32, now time.Time) (string, error) {
+	progression, err := s.Progressions().Get(ctx, id)
+	if err != nil {
+		return "", translate(err, ErrProgressionNotFound)
+	}
+	if progression.Outcome != "" {
+		return "", nil
+	}
+
+	// the taker answered everything and only never submitted, which must not cost them the attempt
+	if progression.IsFinished {
+		if _, err := submitProgression(ctx, s, progression, models.ProgressionOutcomeSubmitted); err != nil {
+			return "", err
+		}
+		return models.ProgressionOutcomeSubmitted, nil
+	}
+	if timeIsUp(progression, now) {
+		_, err := submitProgression(ctx, s, progression, models.ProgressionOutcomeTimedOut)
+		if err != nil {
+			return "", err
+		}
+		return models.ProgressionOutcomeTimedOut, nil
+	}
+
+	progression.IsFinished = true
+	progression.QuestionDeadline = nil
+	progression.Outcome = models.ProgressionOutcomeExpired
+	if err := s.Progressions().Update(ctx, progression); err != nil {
+		return "", err
+	}
+	return models.ProgressionOutcomeExpired, nil
+}
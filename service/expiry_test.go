@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+func TestSweep(t *testing.T) {
+	ctx := context.Background()
+	quizzes, s, taker, quiz := takeable(t, nil)
+
+	// the first attempt is answered to the end but never submitted, the second is left after beginning it
+	answered, question, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for question != nil {
+		result, err := quizzes.Answer(ctx, taker, models.AnswerQuizQuestionRequest{ProgressionID: answered.ID, OptionID: rightOption(t, question)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		question = result.Next
+	}
+	abandoned, _, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiry := NewExpiryService(s, time.Hour)
+	if expired, err := expiry.Sweep(ctx, time.Now()); err != nil || expired != (Expired{}) {
+		t.Fatalf("fresh progressions were closed: %+v, %v", expired, err)
+	}
+	expired, err := expiry.Sweep(ctx, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expired != (Expired{Submitted: 1, Expired: 1}) {
+		t.Fatalf("got %+v, want one submitted and one expired", expired)
+	}
+
+	score, err := s.Scores().GetForProgression(ctx, answered.ID)
+	if err != nil {
+		t.Fatalf("the answered progression got no score: %v", err)
+	}
+	if score.Percentage != 100 {
+		t.Errorf("the answered progression scored %v%%, want 100%%", score.Percentage)
+	}
+	progression, err := s.Progressions().Get(ctx, abandoned.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progression.Outcome != models.ProgressionOutcomeExpired {
+		t.Errorf("the abandoned progression is %q, want %q", progression.Outcome, models.ProgressionOutcomeExpired)
+	}
+	if _, err = quizzes.Submit(ctx, taker, models.FinalizeQuizRequest{ProgressionID: abandoned.ID}); err == nil {
+		t.Error("an expired progression was submitted")
+	}
+}
+
+func TestSweepTimesOut(t *testing.T) {
+	ctx := context.Background()
+	quizzes, s, taker, quiz := takeable(t, func(r *models.CreateQuizRequest) { r.TimeLimit = 3 * 3600 })
+	progression, _, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a progression with a running deadline is not expired for inactivity
+	expiry := NewExpiryService(s, time.Hour)
+	if expired, err := expiry.Sweep(ctx, time.Now().Add(2*time.Hour)); err != nil || expired != (Expired{}) {
+		t.Fatalf("a progression before its deadline was closed: %+v, %v", expired, err)
+	}
+	expired, err := expiry.Sweep(ctx, progression.Deadline.Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expired != (Expired{TimedOut: 1}) {
+		t.Fatalf("got %+v, want one timed out", expired)
+	}
+	if _, err = s.Scores().GetForProgression(ctx, progression.ID); err != nil {
+		t.Fatalf("the timed out progression got no score: %v", err)
+	}
+}
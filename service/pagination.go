@@ -0,0 +1,65 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/validation"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// pageOf applies the defaults to a pagination request, pages start at 1
+func pageOf(p models.PaginationRequest) (page uint32, size uint32) {
+	page, size = p.Page, p.Size
+	if page == 0 {
+		page = 1
+	}
+	if size == 0 {
+		size = DefaultPageSize
+	}
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+	return page, size
+}
+
+// paginate wraps a page of items into a pagination envelope
+func paginate[T any](page uint32, size uint32, total int64, items []T) *models.PaginationResponse {
+	content := make([]any, len(items))
+	for i, item := range items {
+		content[i] = item
+	}
+	return &models.PaginationResponse{
+		Page:       page,
+		Size:       size,
+		Total:      total,
+		TotalPages: uint32((total + int64(size) - 1) / int64(size)),
+		Content:    content,
+	}
+}
+
+const cursorPrefix = "id:"
+
+// encodeCursor returns an opaque cursor pointing after the given id
+func encodeCursor(id uint32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatUint(uint64(id), 10)))
+}
+
+// decodeCursor returns the id a cursor made by encodeCursor points after
+func decodeCursor(cursor string) (uint32, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(b), cursorPrefix) {
+		id, err := strconv.ParseUint(strings.TrimPrefix(string(b), cursorPrefix), 10, 32)
+		if err == nil {
+			return uint32(id), nil
+		}
+	}
+	return 0, ErrValidation.WithDetails(validation.Errors{{Field: "cursor", Message: fmt.Sprintf("%q is not a valid cursor", cursor)}})
+}
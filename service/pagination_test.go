@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+func TestPageOf(t *testing.T) {
+	tests := []struct {
+		request    models.PaginationRequest
+		page, size uint32
+	}{
+		{models.PaginationRequest{}, 1, DefaultPageSize},
+		{models.PaginationRequest{Page: 3, Size: 10}, 3, 10},
+		{models.PaginationRequest{Page: 2, Size: MaxPageSize + 1}, 2, MaxPageSize},
+	}
+	for _, tt := range tests {
+		page, size := pageOf(tt.request)
+		if page != tt.page || size != tt.size {
+			t.Errorf("pageOf(%+v) = %d, %d, want %d, %d", tt.request, page, size, tt.page, tt.size)
+		}
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	for _, id := range []uint32{0, 1, 4294967295} {
+		got, err := decodeCursor(encodeCursor(id))
+		if err != nil || got != id {
+			t.Errorf("decoding the cursor of %d: got %d and error %v", id, got, err)
+		}
+	}
+
+	// not base64, not a cursor, not a number and out of range
+	for _, cursor := range []string{"!!", "MTIz", "aWQ6YWJj", "aWQ6NDI5NDk2NzI5Ng"} {
+		if _, err := decodeCursor(cursor); !errors.Is(err, ErrValidation) {
+			t.Errorf("decodeCursor(%q): got %v, want %v", cursor, err, ErrValidation)
+		}
+	}
+}
+
+func TestListUsersByCursor(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore()
+	for _, name := range []string{"ada", "grace", "linus"} {
+		if err := s.Users().Create(ctx, &models.User{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	users := NewUserService(s)
+
+	first, err := users.ListUsers(ctx, models.ReadUsersRequest{PaginationRequest: models.PaginationRequest{Size: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Content) != 2 || first.Total != 3 || first.NextCursor == nil {
+		t.Fatalf("got %d of %d users with cursor %v, want 2 of 3 with a cursor", len(first.Content), first.Total, first.NextCursor)
+	}
+
+	second, err := users.ListUsers(ctx, models.ReadUsersRequest{PaginationRequest: models.PaginationRequest{Size: 2}, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Content) != 1 || second.Content[0].(models.User).Name != "linus" || second.NextCursor != nil {
+		t.Fatalf("got %+v after the cursor, want only linus and no further cursor", second.Content)
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/store"
+)
+
+// The policies below decide what the authenticated caller may do, admins are allowed everything
+
+func isAdmin(caller *models.User) bool {
+	return caller.Role == models.RoleAdmin
+}
+
+// canAuthor allows authors and admins to create quizzes
+func canAuthor(caller *models.User) error {
+	if isAdmin(caller) || caller.Role == models.RoleAuthor {
+		return nil
+	}
+	return ErrForbidden.WithDetails("only authors and admins can create quizzes")
+}
+
+// canEditQuiz allows the author of the quiz and admins to change it
+func canEditQuiz(caller *models.User, quiz *models.Quiz) error {
+	if isAdmin(caller) || (quiz.AuthorID != 0 && quiz.AuthorID == caller.ID) {
+		return nil
+	}
+	return ErrForbidden.WithDetails("only the author of the quiz and admins can change it")
+}
+
+// canSeeAnswerKey tells whether the caller may see which options of the quiz are correct, which is the same as changing it
+func canSeeAnswerKey(caller *models.User, quiz *models.Quiz) bool {
+	return caller != nil && canEditQuiz(caller, quiz) == nil
+}
+
+// canManageUser allows users to manage themselves and admins to manage everyone
+func canManageUser(caller *models.User, userID uint32) error {
+	if isAdmin(caller) || caller.ID == userID {
+		return nil
+	}
+	return ErrForbidden.WithDetails("users can only access their own account")
+}
+
+// canSeeResults allows the user themselves, the author of the quiz and admins to see the results of a user in a quiz
+func canSeeResults(ctx context.Context, s store.Store, caller *models.User, userID uint32, quizID uint32) error {
+	if isAdmin(caller) || caller.ID == userID {
+		return nil
+	}
+	quiz, err := s.Quizzes().Get(ctx, quizID)
+	if err != nil {
+		return translate(err, ErrQuizNotFound)
+	}
+	if quiz.AuthorID != 0 && quiz.AuthorID == caller.ID {
+		return nil
+	}
+	return ErrForbidden.WithDetails("takers can only see their own results")
+}
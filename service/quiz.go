@@ -0,0 +1,957 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/store"
+	"github.com/lghtr35/quiz-maker/validation"
+)
+
+// QuizService owns quiz authoring and the begin, answer and submit flow of taking a quiz
+type QuizService struct {
+	store store.Store
+}
+
+func NewQuizService(s store.Store) *QuizService {
+	return &QuizService{store: s}
+}
+
+// CreateQuiz inserts the quiz with its questions and options in a single transaction, the caller becomes its author.
+// The quiz starts as a draft and can be taken once it is published.
+func (s *QuizService) CreateQuiz(ctx context.Context, caller *models.User, request models.CreateQuizRequest) (*models.Quiz, error) {
+	if err := canAuthor(caller); err != nil {
+		return nil, err
+	}
+
+	quiz := models.Quiz{
+		Name:              request.Name,
+		AuthorID:          caller.ID,
+		Status:            models.QuizStatusDraft,
+		ShuffleQuestions:  request.ShuffleQuestions,
+		ShuffleOptions:    request.ShuffleOptions,
+		WrongPenalty:      request.WrongPenalty,
+		UnansweredPenalty: request.UnansweredPenalty,
+		PassThreshold:     request.PassThreshold,
+		TimeLimit:         request.TimeLimit,
+		MaxAttempts:       request.MaxAttempts,
+		Cooldown:          request.Cooldown,
+		ScorePolicy:       request.ScorePolicy,
+		Questions:         make([]models.Question, len(request.Questions)),
+	}
+	if quiz.ScorePolicy == "" {
+		quiz.ScorePolicy = models.ScorePolicyBest
+	}
+	for i, q := range request.Questions {
+		quiz.Questions[i] = newQuestion(q)
+		quiz.Questions[i].Position = i
+	}
+
+	err := s.store.Transaction(ctx, func(tx store.Store) error {
+		return tx.Quizzes().Create(ctx, &quiz)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &quiz, nil
+}
+
+// newQuestion builds a question with its options from a request
+func newQuestion(request models.CreateQuestionRequest) models.Question {
+	question := models.Question{
+		Question:  request.Question,
+		Type:      request.Type,
+		Scoring:   request.Scoring,
+		Penalty:   1,
+		Points:    1,
+		TimeLimit: request.TimeLimit,
+	}
+	if question.Type == "" {
+		question.Type = models.QuestionTypeSingle
+	}
+	if question.Scoring == "" {
+		question.Scoring = models.ScoringAllOrNothing
+	}
+	if request.Penalty != nil {
+		question.Penalty = *request.Penalty
+	}
+	if request.Points != nil {
+		question.Points = *request.Points
+	}
+	switch question.Type {
+	case models.QuestionTypeText:
+		question.AcceptedAnswers = request.AcceptedAnswers
+		question.Matching = request.Matching
+		question.CaseSensitive = request.CaseSensitive
+		if question.Matching == "" {
+			question.Matching = models.MatchingExact
+		}
+	case models.QuestionTypeNumeric:
+		question.NumericAnswer = request.NumericAnswer
+		question.Tolerance = request.Tolerance
+	}
+	if request.Options != nil {
+		steps := make([]int, len(*request.Options))
+		for i := range steps {
+			steps[i] = i
+		}
+		if question.Type == models.QuestionTypeOrdering {
+			// the options of an ordering question are given in their correct order, they are saved and shown in a random one
+			// so neither their ids nor their positions tell the correct order apart
+			steps = rand.Perm(len(steps))
+		}
+		question.Options = make([]models.Option, len(*request.Options))
+		for i, step := range steps {
+			o := (*request.Options)[step]
+			question.Options[i] = models.Option{
+				OptionBase: models.OptionBase{
+					Position: i,
+					Value:    o.Value,
+				},
+				IsCorrect: o.IsCorrect && models.IsChoice(question.Type),
+			}
+			switch question.Type {
+			case models.QuestionTypeOrdering:
+				question.Options[i].Step = step
+			case models.QuestionTypeMatching:
+				question.Options[i].Pair = strings.TrimSpace(o.Pair)
+			}
+		}
+	}
+	return question
+}
+
+// ListQuizzes returns a page of quizzes matching the request, without their questions
+func (s *QuizService) ListQuizzes(ctx context.Context, request models.ReadQuizRequest) (*models.PaginationResponse, error) {
+	page, size := pageOf(request.PaginationRequest)
+	filter := store.QuizFilter{
+		Offset: int((page - 1) * size),
+		Limit:  int(size),
+	}
+	if request.IDList != nil {
+		filter.IDs = *request.IDList
+	}
+	if request.Name != nil {
+		filter.Name = *request.Name
+	}
+	if request.Status != nil {
+		filter.Status = *request.Status
+	}
+	if request.Sort != nil {
+		filter.Sort = *request.Sort
+	}
+
+	quizzes, total, err := s.store.Quizzes().List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(page, size, total, quizzes), nil
+}
+
+func (s *QuizService) UpdateQuiz(ctx context.Context, caller *models.User, request models.UpdateQuizRequest) (*models.Quiz, error) {
+	quiz, err := s.editableQuiz(ctx, caller, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil && *request.Name != "" {
+		quiz.Name = *request.Name
+	}
+	// attempts shuffle as the version they take says, so shuffle changes apply once the quiz is published again
+	if request.ShuffleQuestions != nil {
+		quiz.ShuffleQuestions = *request.ShuffleQuestions
+	}
+	if request.ShuffleOptions != nil {
+		quiz.ShuffleOptions = *request.ShuffleOptions
+	}
+	if request.WrongPenalty != nil {
+		quiz.WrongPenalty = *request.WrongPenalty
+	}
+	if request.UnansweredPenalty != nil {
+		quiz.UnansweredPenalty = *request.UnansweredPenalty
+	}
+	if request.PassThreshold != nil {
+		quiz.PassThreshold = *request.PassThreshold
+	}
+	if request.TimeLimit != nil {
+		quiz.TimeLimit = *request.TimeLimit
+	}
+	// attempt settings are not part of versions, they apply to every attempt and score right away
+	if request.MaxAttempts != nil {
+		quiz.MaxAttempts = *request.MaxAttempts
+	}
+	if request.Cooldown != nil {
+		quiz.Cooldown = *request.Cooldown
+	}
+	if request.ScorePolicy != nil && *request.ScorePolicy != "" {
+		quiz.ScorePolicy = *request.ScorePolicy
+	}
+
+	if err = s.store.Quizzes().Update(ctx, quiz); err != nil {
+		return nil, translate(err, ErrQuizNotFound)
+	}
+	return quiz, nil
+}
+
+// GetQuiz returns the quiz with its questions and options and whether the caller may see its answer key.
+// Callers who may not see the answer key get the quiz as it was last published, the content takers are given, rather than edits made since.
+// caller is nil for anonymous requests.
+func (s *QuizService) GetQuiz(ctx context.Context, caller *models.User, id uint32) (*models.Quiz, bool, error) {
+	quiz, err := s.store.Quizzes().Get(ctx, id)
+	if err != nil {
+		return nil, false, translate(err, ErrQuizNotFound)
+	}
+	if canSeeAnswerKey(caller, quiz) {
+		return quiz, true, nil
+	}
+	quiz, err = publishedQuiz(ctx, s.store, quiz)
+	if err != nil {
+		return nil, false, err
+	}
+	return quiz, false, nil
+}
+
+// DeleteQuiz deletes the quiz with every version of it and every attempt, answer and score taken on it
+func (s *QuizService) DeleteQuiz(ctx context.Context, caller *models.User, id uint32) error {
+	if _, err := s.editableQuiz(ctx, caller, id); err != nil {
+		return err
+	}
+	return s.store.Transaction(ctx, func(tx store.Store) error {
+		return translate(tx.Quizzes().Delete(ctx, id), ErrQuizNotFound)
+	})
+}
+
+// Publish checks that every question of the quiz can be answered and scored and makes the quiz available to takers.
+// The quiz is saved as a new version unless it has not changed since it was last published,
+// attempts begun from now on take that version while edits made afterwards wait for the next publish.
+func (s *QuizService) Publish(ctx context.Context, caller *models.User, id uint32) (*models.Quiz, error) {
+	quiz, err := s.editableQuiz(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(quiz.Questions) < 1 {
+		return nil, ErrQuizHasNoQuestions
+	}
+	if errs := models.ValidatePublish(quiz); len(errs) > 0 {
+		return nil, ErrValidation.WithDetails(errs)
+	}
+
+	quiz.Status = models.QuizStatusPublished
+	err = s.store.Transaction(ctx, func(tx store.Store) error {
+		if err := tx.Quizzes().Update(ctx, quiz); err != nil {
+			return translate(err, ErrQuizNotFound)
+		}
+		_, err := currentVersion(ctx, tx, quiz)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return quiz, nil
+}
+
+// Archive stops the quiz from being begun. Attempts already begun can still be finished and every score is kept,
+// publishing the quiz again makes it available once more.
+func (s *QuizService) Archive(ctx context.Context, caller *models.User, id uint32) (*models.Quiz, error) {
+	quiz, err := s.editableQuiz(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz.Status = models.QuizStatusArchived
+	if err = s.store.Quizzes().Update(ctx, quiz); err != nil {
+		return nil, translate(err, ErrQuizNotFound)
+	}
+	return quiz, nil
+}
+
+// editableQuiz returns the quiz when the caller is allowed to change it.
+// Edits never reach progressions and scores, which keep the version of the quiz they were taken on.
+func (s *QuizService) editableQuiz(ctx context.Context, caller *models.User, id uint32) (*models.Quiz, error) {
+	quiz, err := s.store.Quizzes().Get(ctx, id)
+	if err != nil {
+		return nil, translate(err, ErrQuizNotFound)
+	}
+	if err = canEditQuiz(caller, quiz); err != nil {
+		return nil, err
+	}
+	return quiz, nil
+}
+
+// GetQuestion returns the question with its options and whether the caller may see which options are correct.
+// caller is nil for anonymous requests. When a progression of the caller is given the question is returned
+// as it is in the version of the quiz that progression takes, with the options in the order it sees them.
+// Otherwise callers who may not see the answer key get the question as it was last published.
+func (s *QuizService) GetQuestion(ctx context.Context, caller *models.User, id uint32, request models.ReadQuestionRequest) (*models.Question, bool, error) {
+	if request.ProgressionID != 0 {
+		if caller == nil {
+			return nil, false, ErrUnauthenticated
+		}
+		progression, err := ownProgression(ctx, s.store, caller, request.ProgressionID)
+		if err != nil {
+			return nil, false, err
+		}
+		quiz, err := quizOf(ctx, s.store, progression)
+		if err != nil {
+			return nil, false, err
+		}
+		question, ok := findQuestion(quiz, id)
+		if !ok {
+			return nil, false, ErrQuestionNotInQuiz
+		}
+		arrangeOptions(progression, question)
+		return question, canSeeAnswerKey(caller, quiz), nil
+	}
+
+	question, err := s.store.Quizzes().GetQuestion(ctx, id)
+	if err != nil {
+		return nil, false, translate(err, ErrQuestionNotFound)
+	}
+	quiz, err := s.store.Quizzes().Get(ctx, question.QuizID)
+	if err != nil {
+		return nil, false, translate(err, ErrQuizNotFound)
+	}
+	if canSeeAnswerKey(caller, quiz) {
+		return question, true, nil
+	}
+
+	if quiz, err = publishedQuiz(ctx, s.store, quiz); err != nil {
+		return nil, false, err
+	}
+	published, ok := findQuestion(quiz, id)
+	if !ok {
+		return nil, false, ErrQuestionNotFound
+	}
+	return published, false, nil
+}
+
+// CreateQuestion appends a question with its options to a quiz
+func (s *QuizService) CreateQuestion(ctx context.Context, caller *models.User, quizID uint32, request models.CreateQuestionRequest) (*models.Question, error) {
+	quiz, err := s.editableQuiz(ctx, caller, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	question := newQuestion(request)
+	question.QuizID = quizID
+	if n := len(quiz.Questions); n > 0 {
+		question.Position = quiz.Questions[n-1].Position + 1
+	}
+	err = s.store.Transaction(ctx, func(tx store.Store) error {
+		return tx.Quizzes().CreateQuestion(ctx, &question)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+// UpdateQuestion changes the text, type, scoring or answer key of a question
+func (s *QuizService) UpdateQuestion(ctx context.Context, caller *models.User, id uint32, request models.UpdateQuestionRequest) (*models.Question, error) {
+	question, err := s.store.Quizzes().GetQuestion(ctx, id)
+	if err != nil {
+		return nil, translate(err, ErrQuestionNotFound)
+	}
+
+	if request.Question != nil && *request.Question != "" {
+		question.Question = *request.Question
+	}
+	if request.Type != nil && *request.Type != "" && *request.Type != question.Type {
+		// options carry the answer key of the type they were made for, a step or pair does not tell which options are correct
+		if len(question.Options) > 0 {
+			return nil, ErrValidation.WithDetails(validation.Errors{{Field: "type", Message: "cannot change while the question has options, delete them first"}})
+		}
+		question.Type = *request.Type
+	}
+	if request.Scoring != nil && *request.Scoring != "" {
+		question.Scoring = *request.Scoring
+	}
+	if request.Penalty != nil {
+		question.Penalty = *request.Penalty
+	}
+	if request.Points != nil {
+		question.Points = *request.Points
+	}
+	if request.TimeLimit != nil {
+		question.TimeLimit = *request.TimeLimit
+	}
+	if request.AcceptedAnswers != nil {
+		question.AcceptedAnswers = *request.AcceptedAnswers
+	}
+	if request.Matching != nil && *request.Matching != "" {
+		question.Matching = *request.Matching
+	}
+	if request.CaseSensitive != nil {
+		question.CaseSensitive = *request.CaseSensitive
+	}
+	if request.NumericAnswer != nil {
+		question.NumericAnswer = request.NumericAnswer
+	}
+	if request.Tolerance != nil {
+		question.Tolerance = *request.Tolerance
+	}
+	if question.Type == models.QuestionTypeText && question.Matching == "" {
+		question.Matching = models.MatchingExact
+	}
+
+	if _, err = s.editableQuiz(ctx, caller, question.QuizID); err != nil {
+		return nil, err
+	}
+	if errs := models.ValidateQuestion(question); errs != nil {
+		return nil, ErrValidation.WithDetails(errs)
+	}
+
+	if err = s.store.Quizzes().UpdateQuestion(ctx, question); err != nil {
+		return nil, translate(err, ErrQuestionNotFound)
+	}
+	return question, nil
+}
+
+// DeleteQuestion removes a question with its options from a quiz
+func (s *QuizService) DeleteQuestion(ctx context.Context, caller *models.User, id uint32) error {
+	question, err := s.store.Quizzes().GetQuestion(ctx, id)
+	if err != nil {
+		return translate(err, ErrQuestionNotFound)
+	}
+	if _, err = s.editableQuiz(ctx, caller, question.QuizID); err != nil {
+		return err
+	}
+	return translate(s.store.Quizzes().DeleteQuestion(ctx, id), ErrQuestionNotFound)
+}
+
+// ReorderQuestions arranges the questions of a quiz in the order of the given ids
+func (s *QuizService) ReorderQuestions(ctx context.Context, caller *models.User, quizID uint32, request models.ReorderRequest) (*models.Quiz, error) {
+	quiz, err := s.editableQuiz(ctx, caller, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make([]uint32, len(quiz.Questions))
+	for i, q := range quiz.Questions {
+		current[i] = q.ID
+	}
+	if !isPermutation(request.IDs, current) {
+		return nil, ErrValidation.WithDetails(validation.Errors{{Field: "ids", Message: "must contain every question id of the quiz exactly once"}})
+	}
+
+	err = s.store.Transaction(ctx, func(tx store.Store) error {
+		return tx.Quizzes().SetQuestionPositions(ctx, request.IDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.editableQuiz(ctx, caller, quizID)
+}
+
+// ReorderOptions arranges the options of a question in the order of the given ids
+func (s *QuizService) ReorderOptions(ctx context.Context, caller *models.User, questionID uint32, request models.ReorderRequest) (*models.Question, error) {
+	question, err := s.store.Quizzes().GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, translate(err, ErrQuestionNotFound)
+	}
+	if _, err = s.editableQuiz(ctx, caller, question.QuizID); err != nil {
+		return nil, err
+	}
+
+	current := make([]uint32, len(question.Options))
+	for i, o := range question.Options {
+		current[i] = o.ID
+	}
+	if !isPermutation(request.IDs, current) {
+		return nil, ErrValidation.WithDetails(validation.Errors{{Field: "ids", Message: "must contain every option id of the question exactly once"}})
+	}
+
+	err = s.store.Transaction(ctx, func(tx store.Store) error {
+		return tx.Quizzes().SetOptionPositions(ctx, request.IDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	question, err = s.store.Quizzes().GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, translate(err, ErrQuestionNotFound)
+	}
+	return question, nil
+}
+
+// isPermutation reports whether ids holds exactly the ids of current in any order
+func isPermutation(ids []uint32, current []uint32) bool {
+	if len(ids) != len(current) {
+		return false
+	}
+	remaining := make(map[uint32]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return false
+		}
+		delete(remaining, id)
+	}
+	return true
+}
+
+// CreateOption adds an option to a question of a quiz
+func (s *QuizService) CreateOption(ctx context.Context, caller *models.User, questionID uint32, request models.CreateOptionRequest) (*models.Option, error) {
+	question, err := s.store.Quizzes().GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, translate(err, ErrQuestionNotFound)
+	}
+	if _, err = s.editableQuiz(ctx, caller, question.QuizID); err != nil {
+		return nil, err
+	}
+	if !models.HasOptions(question.Type) {
+		return nil, ErrQuestionTakesNoOptions
+	}
+	if request.IsCorrect && !models.IsChoice(question.Type) {
+		return nil, ErrValidation.WithDetails(validation.Errors{{Field: "isCorrect", Message: "only applies to single and multiple questions"}})
+	}
+
+	option := models.Option{
+		OptionBase: models.OptionBase{
+			QuestionID: question.ID,
+			Value:      request.Value,
+		},
+		IsCorrect: request.IsCorrect,
+	}
+	if n := len(question.Options); n > 0 {
+		option.Position = question.Options[n-1].Position + 1
+	}
+	switch question.Type {
+	case models.QuestionTypeOrdering:
+		// a new option goes last in the correct order, its step can be changed afterwards
+		for _, o := range question.Options {
+			option.Step = max(option.Step, o.Step+1)
+		}
+	case models.QuestionTypeMatching:
+		option.Pair = strings.TrimSpace(request.Pair)
+		if option.Pair == "" {
+			return nil, ErrValidation.WithDetails(validation.Errors{{Field: "pair", Message: "is required for matching questions"}})
+		}
+	}
+
+	err = s.store.Transaction(ctx, func(tx store.Store) error {
+		if err := tx.Quizzes().CreateOption(ctx, &option); err != nil {
+			return err
+		}
+		if question.Type != models.QuestionTypeOrdering {
+			return nil
+		}
+		// the new option of an ordering question takes a random position so being shown last does not give its step away
+		ids := make([]uint32, 0, len(question.Options)+1)
+		for _, o := range question.Options {
+			ids = append(ids, o.ID)
+		}
+		at := rand.IntN(len(ids) + 1)
+		ids = slices.Insert(ids, at, option.ID)
+		option.Position = at
+		return tx.Quizzes().SetOptionPositions(ctx, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &option, nil
+}
+
+// UpdateOption changes the value, correctness, step or pair of an option
+func (s *QuizService) UpdateOption(ctx context.Context, caller *models.User, id uint32, request models.UpdateOptionRequest) (*models.Option, error) {
+	option, err := s.store.Quizzes().GetOption(ctx, id)
+	if err != nil {
+		return nil, translate(err, ErrOptionNotFound)
+	}
+	question, err := s.store.Quizzes().GetQuestion(ctx, option.QuestionID)
+	if err != nil {
+		return nil, translate(err, ErrQuestionNotFound)
+	}
+	if request.Pair != nil {
+		trimmed := strings.TrimSpace(*request.Pair)
+		request.Pair = &trimmed
+		if question.Type == models.QuestionTypeMatching && trimmed == "" {
+			return nil, ErrValidation.WithDetails(validation.Errors{{Field: "pair", Message: "is required for matching questions"}})
+		}
+	}
+	if request.IsCorrect != nil && *request.IsCorrect && !models.IsChoice(question.Type) {
+		return nil, ErrValidation.WithDetails(validation.Errors{{Field: "isCorrect", Message: "only applies to single and multiple questions"}})
+	}
+
+	if _, err = s.editableQuiz(ctx, caller, question.QuizID); err != nil {
+		return nil, err
+	}
+
+	if request.Value != nil && *request.Value != "" {
+		option.Value = *request.Value
+	}
+	if request.IsCorrect != nil {
+		option.IsCorrect = *request.IsCorrect
+	}
+	if request.Step != nil {
+		option.Step = *request.Step
+	}
+	if request.Pair != nil {
+		option.Pair = *request.Pair
+	}
+
+	if err = s.store.Quizzes().UpdateOption(ctx, option); err != nil {
+		return nil, translate(err, ErrOptionNotFound)
+	}
+	return option, nil
+}
+
+// DeleteOption removes an option from a question of a quiz
+func (s *QuizService) DeleteOption(ctx context.Context, caller *models.User, id uint32) error {
+	option, err := s.store.Quizzes().GetOption(ctx, id)
+	if err != nil {
+		return translate(err, ErrOptionNotFound)
+	}
+	question, err := s.store.Quizzes().GetQuestion(ctx, option.QuestionID)
+	if err != nil {
+		return translate(err, ErrQuestionNotFound)
+	}
+	if _, err = s.editableQuiz(ctx, caller, question.QuizID); err != nil {
+		return err
+	}
+	return translate(s.store.Quizzes().DeleteOption(ctx, id), ErrOptionNotFound)
+}
+
+// Begin starts a new progression of the caller on a published quiz and returns it with its first question.
+// Questions and options are shuffled for the progression when the quiz asks for it.
+// The caller cannot begin more attempts than the quiz allows, nor begin one before the cooldown since their last attempt passed.
+func (s *QuizService) Begin(ctx context.Context, caller *models.User, request models.BeginQuizRequest) (*models.Progression, *models.Question, error) {
+	// Get quiz and check if it is okay to start progressing on it
+	quiz, err := s.store.Quizzes().Get(ctx, request.QuizID)
+	if err != nil {
+		return nil, nil, translate(err, ErrQuizNotFound)
+	}
+	if quiz.Status != models.QuizStatusPublished {
+		return nil, nil, ErrQuizNotPublished
+	}
+
+	// Create a new progression for user to keep track of where we are at
+	progression := models.Progression{
+		UserID:         caller.ID,
+		QuizID:         request.QuizID,
+		IsFinished:     false,
+		QuestionNumber: 0,
+		Seed:           rand.Int64(),
+	}
+	now := time.Now()
+	live := quiz
+	err = s.store.Transaction(ctx, func(tx store.Store) error {
+		attempt, err := nextAttempt(ctx, tx, caller.ID, live, now)
+		if err != nil {
+			return err
+		}
+		progression.Attempt = attempt
+
+		// the progression takes the version saved when the quiz was last published, edits made since wait for the next publish
+		version, err := tx.Versions().Latest(ctx, quiz.ID)
+		if errors.Is(err, store.ErrNotFound) {
+			// quizzes published before publishing saved versions get their first one now
+			version, err = currentVersion(ctx, tx, quiz)
+		}
+		if err != nil {
+			return err
+		}
+		quiz = version.Snapshot
+		if len(quiz.Questions) < 1 {
+			return ErrQuizHasNoQuestions
+		}
+		progression.VersionID = version.ID
+		arrange(&progression, quiz)
+		progression.CurrentQuestionID = progression.QuestionOrder[0]
+		startTimers(&progression, quiz, now)
+		err = tx.Progressions().Create(ctx, &progression)
+		if errors.Is(err, store.ErrConflict) {
+			// another begin of the caller took the same attempt meanwhile
+			if live.MaxAttempts > 0 && progression.Attempt >= live.MaxAttempts {
+				return noAttemptsLeft(live)
+			}
+			return ErrConflict.WithDetails("another attempt on the quiz began at the same time")
+		}
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &progression, currentQuestion(&progression, quiz), nil
+}
+
+// Answered is where a progression stands after an answer
+type Answered struct {
+	Progression *models.Progression
+	// Next is the question to answer next, nil once every question is answered
+	Next *models.Question
+	// TimedOut tells that the answer came too late and was not saved
+	TimedOut bool
+	// Score is set when the progression was submitted because the quiz ran out of time
+	Score *models.Score
+}
+
+// Answer saves the chosen option for the current question and moves the progression to the next question,
+// which is returned unless every question has been answered. Only the user who began the progression can answer it.
+// An answer after the current question timed out is dropped and the progression moves on,
+// an answer after the quiz ran out of time is dropped and the progression is submitted.
+func (s *QuizService) Answer(ctx context.Context, caller *models.User, request models.AnswerQuizQuestionRequest) (*Answered, error) {
+	var answered *Answered
+	err := s.store.Transaction(ctx, func(tx store.Store) error {
+		var err error
+		answered, err = answer(ctx, tx, caller, request, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return answered, nil
+}
+
+func answer(ctx context.Context, s store.Store, caller *models.User, request models.AnswerQuizQuestionRequest, now time.Time) (*Answered, error) {
+	// Get progression to check if it is okay to answer new questions
+	// if it is ok, get question that we are going to answer
+	progression, err := ownProgression(ctx, s, caller, request.ProgressionID)
+	if err != nil {
+		return nil, err
+	}
+	if progression.Outcome == models.ProgressionOutcomeExpired {
+		return nil, ErrProgressionExpired
+	}
+	if progression.IsFinished {
+		return nil, ErrQuizFinished
+	}
+	if timeIsUp(progression, now) {
+		score, err := submitProgression(ctx, s, progression, models.ProgressionOutcomeTimedOut)
+		if err != nil {
+			return nil, err
+		}
+		return &Answered{Progression: progression, TimedOut: true, Score: score}, nil
+	}
+
+	// the progression is answered on the version of the quiz it began with
+	quiz, err := quizOf(ctx, s, progression)
+	if err != nil {
+		return nil, err
+	}
+	// check if question has that option that user is trying to select
+	// if all good select option and save answer
+	question, ok := findQuestion(quiz, progression.CurrentQuestionID)
+	if !ok {
+		return nil, ErrQuestionNotFound
+	}
+
+	// a question that timed out is left unanswered
+	timedOut := questionTimeIsUp(progression, now)
+	if !timedOut {
+		answers, err := newAnswers(progression, question, request)
+		if err != nil {
+			return nil, err
+		}
+		for i := range answers {
+			if err = s.Answers().Create(ctx, &answers[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// fetch new question for progression or finish the progression
+	progression.QuestionNumber++
+	order := questionOrder(progression, quiz)
+	if len(order) > progression.QuestionNumber {
+		progression.CurrentQuestionID = order[progression.QuestionNumber]
+	} else {
+		progression.IsFinished = true
+	}
+	startQuestion(progression, quiz, now)
+
+	if err = s.Progressions().Update(ctx, progression); err != nil {
+		return nil, err
+	}
+	answered := &Answered{Progression: progression, TimedOut: timedOut}
+	if !progression.IsFinished {
+		answered.Next = currentQuestion(progression, quiz)
+	}
+	return answered, nil
+}
+
+// newAnswers builds the answers the request gives to the question in the type of the question.
+// Every picked option of a multiple question is saved as an answer of its own.
+func newAnswers(progression *models.Progression, question *models.Question, request models.AnswerQuizQuestionRequest) ([]models.Answer, error) {
+	base := models.Answer{
+		UserID:        progression.UserID,
+		QuizID:        progression.QuizID,
+		ProgressionID: progression.ID,
+		QuestionID:    question.ID,
+	}
+
+	switch question.Type {
+	case models.QuestionTypeText:
+		if request.Text == nil {
+			return nil, ErrAnswerTypeMismatch.WithDetails("text questions are answered with text")
+		}
+		base.Text = *request.Text
+		return []models.Answer{base}, nil
+	case models.QuestionTypeNumeric:
+		if request.Number == nil {
+			return nil, ErrAnswerTypeMismatch.WithDetails("numeric questions are answered with number")
+		}
+		base.Number = request.Number
+		return []models.Answer{base}, nil
+	case models.QuestionTypeOrdering:
+		if len(request.Order) == 0 {
+			return nil, ErrAnswerTypeMismatch.WithDetails("ordering questions are answered with order")
+		}
+		ids := make([]uint32, len(question.Options))
+		for i, o := range question.Options {
+			ids[i] = o.ID
+		}
+		if !isPermutation(request.Order, ids) {
+			return nil, ErrValidation.WithDetails(validation.Errors{{Field: "order", Message: "must contain every option id of the question exactly once"}})
+		}
+		answers := make([]models.Answer, len(request.Order))
+		for i, id := range request.Order {
+			answers[i] = base
+			answers[i].OptionID = id
+			answers[i].Position = i
+		}
+		return answers, nil
+	case models.QuestionTypeMatching:
+		if len(request.Pairs) == 0 {
+			return nil, ErrAnswerTypeMismatch.WithDetails("matching questions are answered with pairs")
+		}
+		answers := make([]models.Answer, len(request.Pairs))
+		for i, p := range request.Pairs {
+			if !slices.ContainsFunc(question.Options, func(o models.Option) bool { return o.ID == p.OptionID }) {
+				return nil, ErrOptionNotInQuestion.WithDetails(p.OptionID)
+			}
+			answers[i] = base
+			answers[i].OptionID = p.OptionID
+			answers[i].Text = strings.TrimSpace(p.Pair)
+		}
+		return answers, nil
+	}
+
+	chosen := request.ChosenOptions()
+	if len(chosen) == 0 {
+		return nil, ErrAnswerTypeMismatch.WithDetails(question.Type + " questions are answered with options")
+	}
+	if question.Type != models.QuestionTypeMultiple && len(chosen) != 1 {
+		return nil, ErrSingleOptionQuestion
+	}
+	answers := make([]models.Answer, len(chosen))
+	for i, id := range chosen {
+		isOptionInQuestion := false
+		for _, o := range question.Options {
+			if o.ID == id {
+				isOptionInQuestion = true
+				break
+			}
+		}
+		if !isOptionInQuestion {
+			return nil, ErrOptionNotInQuestion.WithDetails(id)
+		}
+		answers[i] = base
+		answers[i].OptionID = id
+	}
+	return answers, nil
+}
+
+// currentQuestion returns the question the progression is at with its options in the order the progression sees them
+func currentQuestion(progression *models.Progression, quiz *models.Quiz) *models.Question {
+	for i := range quiz.Questions {
+		if quiz.Questions[i].ID == progression.CurrentQuestionID {
+			question := quiz.Questions[i]
+			arrangeOptions(progression, &question)
+			return &question
+		}
+	}
+	return nil
+}
+
+// Submit finalizes the progression and saves the score calculated from the given answers.
+// Only the user who began the progression can submit it, a submit after its deadline times it out.
+func (s *QuizService) Submit(ctx context.Context, caller *models.User, request models.FinalizeQuizRequest) (*models.Score, error) {
+	var score *models.Score
+	err := s.store.Transaction(ctx, func(tx store.Store) error {
+		var err error
+		score, err = submit(ctx, tx, caller, request, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return score, nil
+}
+
+func submit(ctx context.Context, s store.Store, caller *models.User, request models.FinalizeQuizRequest, now time.Time) (*models.Score, error) {
+	progression, err := ownProgression(ctx, s, caller, request.ProgressionID)
+	if err != nil {
+		return nil, err
+	}
+	if timeIsUp(progression, now) {
+		return submitProgression(ctx, s, progression, models.ProgressionOutcomeTimedOut)
+	}
+	return submitProgression(ctx, s, progression, models.ProgressionOutcomeSubmitted)
+}
+
+// submitProgression finishes the progression and saves its score, answers given after its deadline were never saved
+func submitProgression(ctx context.Context, s store.Store, progression *models.Progression, outcome string) (*models.Score, error) {
+	if progression.Outcome == models.ProgressionOutcomeExpired {
+		return nil, ErrProgressionExpired
+	}
+	_, err := s.Scores().GetForProgression(ctx, progression.ID)
+	if !errors.Is(err, store.ErrNotFound) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrProgressionSubmitted
+	}
+	progression.IsFinished = true
+	progression.QuestionDeadline = nil
+	progression.Outcome = outcome
+
+	quiz, err := quizOf(ctx, s, progression)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(quiz.Questions) == 0 {
+		return nil, ErrQuizHasNoQuestions
+	}
+
+	// get answers given in this progression
+	answers, err := s.Answers().ListForProgression(ctx, progression.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	score := calculateScore(quiz, gradeQuestions(quiz, answers))
+	score.UserID = progression.UserID
+	score.ProgressionID = progression.ID
+	score.VersionID = progression.VersionID
+	score.Attempt = progression.Attempt
+	if err = s.Scores().Create(ctx, &score); err != nil {
+		return nil, err
+	}
+
+	// The progression is kept finished so the analysis can show the order it was taken in
+	if err = s.Progressions().Update(ctx, progression); err != nil {
+		return nil, err
+	}
+	return &score, nil
+}
+
+// ownProgression returns the progression when it was begun by the caller
+func ownProgression(ctx context.Context, s store.Store, caller *models.User, id uint32) (*models.Progression, error) {
+	progression, err := s.Progressions().Get(ctx, id)
+	if err != nil {
+		return nil, translate(err, ErrProgressionNotFound)
+	}
+	if progression.UserID != caller.ID {
+		return nil, ErrProgressionNotOwned
+	}
+	return progression, nil
+}
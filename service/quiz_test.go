@@ -0,0 +1,522 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+func TestNewQuestionHidesOrderingSteps(t *testing.T) {
+	options := make([]models.CreateOptionRequest, 6)
+	for i := range options {
+		options[i] = models.CreateOptionRequest{Value: fmt.Sprintf("step %d", i)}
+	}
+	request := models.CreateQuestionRequest{Question: "order", Type: models.QuestionTypeOrdering, Options: &options}
+
+	inOrder := 0
+	for range 50 {
+		question := newQuestion(request)
+		steps := make([]int, len(question.Options))
+		for i, o := range question.Options {
+			// options are saved in slice order, so the index is both their id order and their position
+			if o.Position != i {
+				t.Fatalf("option %d has position %d", i, o.Position)
+			}
+			if o.Value != fmt.Sprintf("step %d", o.Step) {
+				t.Fatalf("option %q has step %d", o.Value, o.Step)
+			}
+			steps[i] = o.Step
+		}
+		sorted := slices.Clone(steps)
+		slices.Sort(sorted)
+		if !slices.Equal(sorted, []int{0, 1, 2, 3, 4, 5}) {
+			t.Fatalf("steps %v are not a permutation", steps)
+		}
+		if slices.IsSorted(steps) {
+			inOrder++
+		}
+	}
+	if inOrder == 50 {
+		t.Fatal("ids and positions follow the correct order every time")
+	}
+}
+
+// takeable sets up a published quiz of two questions whose first option is the correct one,
+// returning the quiz service, the taker and the quiz. configure, when set, adjusts the quiz before it is created
+func takeable(t *testing.T, configure func(*models.CreateQuizRequest)) (*QuizService, *memoryStore, *models.User, *models.Quiz) {
+	t.Helper()
+	ctx := context.Background()
+	s := newMemoryStore()
+	author := &models.User{Name: "author", Role: models.RoleAuthor}
+	taker := &models.User{Name: "taker", Role: models.RoleTaker}
+	for _, u := range []*models.User{author, taker} {
+		if err := s.Users().Create(ctx, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	options := []models.CreateOptionRequest{{Value: "right", IsCorrect: true}, {Value: "wrong"}}
+	request := models.CreateQuizRequest{
+		Name: "quiz",
+		Questions: []models.CreateQuestionRequest{
+			{Question: "first?", Options: &options},
+			{Question: "second?", Options: &options},
+		},
+	}
+	if configure != nil {
+		configure(&request)
+	}
+	quizzes := NewQuizService(s)
+	quiz, err := quizzes.CreateQuiz(ctx, author, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quiz, err = quizzes.Publish(ctx, author, quiz.ID); err != nil {
+		t.Fatal(err)
+	}
+	return quizzes, s, taker, quiz
+}
+
+// rightOption returns the id of the correct option of the question
+func rightOption(t *testing.T, question *models.Question) uint32 {
+	t.Helper()
+	for _, o := range question.Options {
+		if o.IsCorrect {
+			return o.ID
+		}
+	}
+	t.Fatalf("question %d has no correct option", question.ID)
+	return 0
+}
+
+// wrongOption returns the id of an incorrect option of the question
+func wrongOption(t *testing.T, question *models.Question) uint32 {
+	t.Helper()
+	for _, o := range question.Options {
+		if !o.IsCorrect {
+			return o.ID
+		}
+	}
+	t.Fatalf("question %d has no wrong option", question.ID)
+	return 0
+}
+
+func TestBeginAnswerSubmit(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, taker, quiz := takeable(t, nil)
+
+	progression, question, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progression.Attempt != 1 || question.ID != progression.CurrentQuestionID {
+		t.Fatalf("began attempt %d at question %d but got question %d", progression.Attempt, progression.CurrentQuestionID, question.ID)
+	}
+
+	// the first question is answered right, the second wrong
+	answered, err := quizzes.Answer(ctx, taker, models.AnswerQuizQuestionRequest{ProgressionID: progression.ID, OptionID: rightOption(t, question)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answered.Next == nil || answered.Progression.IsFinished {
+		t.Fatal("progression finished after the first of two questions")
+	}
+	wrong := wrongOption(t, answered.Next)
+	if answered, err = quizzes.Answer(ctx, taker, models.AnswerQuizQuestionRequest{ProgressionID: progression.ID, OptionID: wrong}); err != nil {
+		t.Fatal(err)
+	}
+	if answered.Next != nil || !answered.Progression.IsFinished {
+		t.Fatal("progression is not finished after every question was answered")
+	}
+	if _, err = quizzes.Answer(ctx, taker, models.AnswerQuizQuestionRequest{ProgressionID: progression.ID, OptionID: wrong}); !errors.Is(err, ErrQuizFinished) {
+		t.Fatalf("answering a finished progression: got %v, want %v", err, ErrQuizFinished)
+	}
+
+	score, err := quizzes.Submit(ctx, taker, models.FinalizeQuizRequest{ProgressionID: progression.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Percentage != 50 || score.RawPoints != 1 || score.MaxPoints != 2 || score.ProgressionID != progression.ID || score.Attempt != 1 {
+		t.Fatalf("got %v%% with %v of %v points for progression %d on attempt %d, want 50%% with 1 of 2 for %d on attempt 1",
+			score.Percentage, score.RawPoints, score.MaxPoints, score.ProgressionID, score.Attempt, progression.ID)
+	}
+	if _, err = quizzes.Submit(ctx, taker, models.FinalizeQuizRequest{ProgressionID: progression.ID}); !errors.Is(err, ErrProgressionSubmitted) {
+		t.Fatalf("submitting twice: got %v, want %v", err, ErrProgressionSubmitted)
+	}
+}
+
+func TestBeginChecksTheQuiz(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, taker, quiz := takeable(t, func(r *models.CreateQuizRequest) { r.MaxAttempts = 1 })
+	author := &models.User{Base: models.Base{ID: quiz.AuthorID}, Role: models.RoleAuthor}
+
+	if _, _, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID + 100}); !errors.Is(err, ErrQuizNotFound) {
+		t.Fatalf("beginning a missing quiz: got %v, want %v", err, ErrQuizNotFound)
+	}
+	if _, _, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID}); !errors.Is(err, ErrNoAttemptsLeft) {
+		t.Fatalf("beginning past the attempt limit: got %v, want %v", err, ErrNoAttemptsLeft)
+	}
+
+	empty, err := quizzes.CreateQuiz(ctx, author, models.CreateQuizRequest{Name: "empty"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: empty.ID}); !errors.Is(err, ErrQuizNotPublished) {
+		t.Fatalf("beginning a draft: got %v, want %v", err, ErrQuizNotPublished)
+	}
+	if _, err = quizzes.Publish(ctx, author, empty.ID); !errors.Is(err, ErrQuizHasNoQuestions) {
+		t.Fatalf("publishing a quiz without questions: got %v, want %v", err, ErrQuizHasNoQuestions)
+	}
+
+	if _, err = quizzes.Archive(ctx, author, quiz.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID}); !errors.Is(err, ErrQuizNotPublished) {
+		t.Fatalf("beginning an archived quiz: got %v, want %v", err, ErrQuizNotPublished)
+	}
+}
+
+func TestAnswerChecksTheOption(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, taker, quiz := takeable(t, nil)
+
+	progression, _, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// an option of the second question does not answer the first one
+	other := rightOption(t, &quiz.Questions[1])
+	if _, err = quizzes.Answer(ctx, taker, models.AnswerQuizQuestionRequest{ProgressionID: progression.ID, OptionID: other}); !errors.Is(err, ErrOptionNotInQuestion) {
+		t.Fatalf("answering with another question's option: got %v, want %v", err, ErrOptionNotInQuestion)
+	}
+	// a single question takes exactly one option
+	both := []uint32{rightOption(t, &quiz.Questions[0]), wrongOption(t, &quiz.Questions[0])}
+	if _, err = quizzes.Answer(ctx, taker, models.AnswerQuizQuestionRequest{ProgressionID: progression.ID, OptionIDs: both}); !errors.Is(err, ErrSingleOptionQuestion) {
+		t.Fatalf("answering a single question with two options: got %v, want %v", err, ErrSingleOptionQuestion)
+	}
+}
+
+func TestAnswerOnlyByItsTaker(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, taker, quiz := takeable(t, nil)
+	progression, question, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := &models.User{Base: models.Base{ID: taker.ID + 100}}
+	request := models.AnswerQuizQuestionRequest{ProgressionID: progression.ID, OptionID: rightOption(t, question)}
+	if _, err = quizzes.Answer(ctx, other, request); !errors.Is(err, ErrProgressionNotOwned) {
+		t.Fatalf("answering another user's progression: got %v, want %v", err, ErrProgressionNotOwned)
+	}
+	if _, err = quizzes.Submit(ctx, other, models.FinalizeQuizRequest{ProgressionID: progression.ID}); !errors.Is(err, ErrProgressionNotOwned) {
+		t.Fatalf("submitting another user's progression: got %v, want %v", err, ErrProgressionNotOwned)
+	}
+
+	request.OptionID = 0
+	if _, err = quizzes.Answer(ctx, taker, request); !errors.Is(err, ErrAnswerTypeMismatch) {
+		t.Fatalf("answering without an option: got %v, want %v", err, ErrAnswerTypeMismatch)
+	}
+}
+
+func TestAnswerAfterTheDeadlineSubmits(t *testing.T) {
+	ctx := context.Background()
+	quizzes, s, taker, quiz := takeable(t, func(r *models.CreateQuizRequest) { r.TimeLimit = 60 })
+	progression, question, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	late := progression.Deadline.Add(time.Second)
+	request := models.AnswerQuizQuestionRequest{ProgressionID: progression.ID, OptionID: rightOption(t, question)}
+	answered, err := answer(ctx, s, taker, request, late)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !answered.TimedOut || answered.Score == nil || answered.Progression.Outcome != models.ProgressionOutcomeTimedOut {
+		t.Fatalf("a late answer did not time the progression out: %+v", answered)
+	}
+	if answered.Score.RawPoints != 0 {
+		t.Fatalf("the late answer was scored, got %v points", answered.Score.RawPoints)
+	}
+}
+
+func TestSubmitAfterTheDeadlineTimesOut(t *testing.T) {
+	ctx := context.Background()
+	quizzes, s, taker, quiz := takeable(t, func(r *models.CreateQuizRequest) { r.TimeLimit = 60 })
+	progression, _, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := models.FinalizeQuizRequest{ProgressionID: progression.ID}
+	if _, err = submit(ctx, s, taker, request, progression.Deadline.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if progression, err = s.Progressions().Get(ctx, progression.ID); err != nil {
+		t.Fatal(err)
+	}
+	if progression.Outcome != models.ProgressionOutcomeTimedOut {
+		t.Fatalf("a late submit closed the progression as %q, want %q", progression.Outcome, models.ProgressionOutcomeTimedOut)
+	}
+}
+
+func TestOnlyItsAuthorEditsAQuiz(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, taker, quiz := takeable(t, nil)
+	name := "renamed"
+	request := models.UpdateQuizRequest{ID: quiz.ID, Name: &name}
+
+	if _, err := quizzes.CreateQuiz(ctx, taker, models.CreateQuizRequest{Name: "mine"}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("creating a quiz as a taker: got %v, want %v", err, ErrForbidden)
+	}
+	other := &models.User{Base: models.Base{ID: quiz.AuthorID + 100}, Role: models.RoleAuthor}
+	if _, err := quizzes.UpdateQuiz(ctx, other, request); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("updating another author's quiz: got %v, want %v", err, ErrForbidden)
+	}
+	if err := quizzes.DeleteQuiz(ctx, other, quiz.ID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("deleting another author's quiz: got %v, want %v", err, ErrForbidden)
+	}
+
+	admin := &models.User{Base: models.Base{ID: quiz.AuthorID + 200}, Role: models.RoleAdmin}
+	updated, err := quizzes.UpdateQuiz(ctx, admin, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Name != name || updated.AuthorID != quiz.AuthorID {
+		t.Fatalf("got quiz %q by %d, want %q by %d", updated.Name, updated.AuthorID, name, quiz.AuthorID)
+	}
+}
+
+func TestAnswerKeyIsForEditors(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, taker, quiz := takeable(t, nil)
+	author := &models.User{Base: models.Base{ID: quiz.AuthorID}, Role: models.RoleAuthor}
+
+	for _, tt := range []struct {
+		name   string
+		caller *models.User
+		want   bool
+	}{
+		{"anonymous", nil, false},
+		{"taker", taker, false},
+		{"author", author, true},
+	} {
+		_, quizKey, err := quizzes.GetQuiz(ctx, tt.caller, quiz.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		full, _, err := quizzes.GetQuiz(ctx, author, quiz.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, questionKey, err := quizzes.GetQuestion(ctx, tt.caller, full.Questions[0].ID, models.ReadQuestionRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if quizKey != tt.want || questionKey != tt.want {
+			t.Errorf("%s sees the answer key of the quiz %v and of its question %v, want %v", tt.name, quizKey, questionKey, tt.want)
+		}
+	}
+}
+
+func TestTakersGetThePublishedQuestion(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, taker, quiz := takeable(t, nil)
+	author := &models.User{Base: models.Base{ID: quiz.AuthorID}, Role: models.RoleAuthor}
+
+	// the author edits a question and adds another without publishing again
+	id := quiz.Questions[0].ID
+	edited := "edited?"
+	if _, err := quizzes.UpdateQuestion(ctx, author, id, models.UpdateQuestionRequest{Question: &edited}); err != nil {
+		t.Fatal(err)
+	}
+	options := []models.CreateOptionRequest{{Value: "right", IsCorrect: true}, {Value: "wrong"}}
+	added, err := quizzes.CreateQuestion(ctx, author, quiz.ID, models.CreateQuestionRequest{Question: "third?", Options: &options})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, caller := range []*models.User{nil, taker} {
+		question, _, err := quizzes.GetQuestion(ctx, caller, id, models.ReadQuestionRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if question.Question != "first?" {
+			t.Errorf("got question %q, want the published %q", question.Question, "first?")
+		}
+		if _, _, err = quizzes.GetQuestion(ctx, caller, added.ID, models.ReadQuestionRequest{}); !errors.Is(err, ErrQuestionNotFound) {
+			t.Errorf("getting an unpublished question: got %v, want %v", err, ErrQuestionNotFound)
+		}
+	}
+	question, _, err := quizzes.GetQuestion(ctx, author, id, models.ReadQuestionRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if question.Question != edited {
+		t.Errorf("the author got question %q, want the edited %q", question.Question, edited)
+	}
+}
+
+func TestProgressionsKeepTheirVersion(t *testing.T) {
+	ctx := context.Background()
+	quizzes, s, taker, quiz := takeable(t, nil)
+	progression, _, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the author drops a question after the progression began
+	if err = s.Quizzes().DeleteQuestion(ctx, quiz.Questions[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	score, err := quizzes.Submit(ctx, taker, models.FinalizeQuizRequest{ProgressionID: progression.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.MaxPoints != 2 || score.VersionID != progression.VersionID {
+		t.Fatalf("scored %v points on version %d, want 2 on version %d", score.MaxPoints, score.VersionID, progression.VersionID)
+	}
+
+	// edits reach new progressions once the quiz is published again
+	next, _, err := quizzes.Begin(ctx, taker, models.BeginQuizRequest{QuizID: quiz.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.VersionID != progression.VersionID {
+		t.Fatalf("began on version %d before publishing the edit, want %d", next.VersionID, progression.VersionID)
+	}
+	author := &models.User{Base: models.Base{ID: quiz.AuthorID}, Role: models.RoleAuthor}
+	if _, err = quizzes.Publish(ctx, author, quiz.ID); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := s.Versions().Latest(ctx, quiz.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Number != 2 || len(latest.Snapshot.Questions) != 1 {
+		t.Fatalf("published version %d with %d questions, want version 2 with 1", latest.Number, len(latest.Snapshot.Questions))
+	}
+}
+
+func TestReadVersions(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, taker, quiz := takeable(t, nil)
+	author := &models.User{Base: models.Base{ID: quiz.AuthorID}, Role: models.RoleAuthor}
+
+	for _, tt := range []struct {
+		name   string
+		caller *models.User
+		want   bool
+	}{
+		{"anonymous", nil, false},
+		{"taker", taker, false},
+		{"author", author, true},
+	} {
+		versions, err := quizzes.ListVersions(ctx, tt.caller, quiz.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(versions) != 1 || versions[0].Number != 1 || versions[0].Snapshot != nil {
+			t.Fatalf("%s got versions %+v, want version 1 without its snapshot", tt.name, versions)
+		}
+		version, full, err := quizzes.GetVersion(ctx, tt.caller, quiz.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version.Snapshot == nil || full != tt.want {
+			t.Errorf("%s sees the answer key of version 1 %v, want %v", tt.name, full, tt.want)
+		}
+	}
+
+	if _, err := quizzes.ListVersions(ctx, taker, quiz.ID+100); !errors.Is(err, ErrQuizNotFound) {
+		t.Fatalf("listing the versions of a missing quiz: got %v, want %v", err, ErrQuizNotFound)
+	}
+	if _, _, err := quizzes.GetVersion(ctx, taker, quiz.ID, 2); !errors.Is(err, ErrVersionNotFound) {
+		t.Fatalf("getting a missing version: got %v, want %v", err, ErrVersionNotFound)
+	}
+}
+
+func TestQuestionTypeChangesWithoutOptions(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, _, quiz := takeable(t, nil)
+	author := &models.User{Base: models.Base{ID: quiz.AuthorID}, Role: models.RoleAuthor}
+
+	ordering := models.QuestionTypeOrdering
+	request := models.UpdateQuestionRequest{Type: &ordering}
+	if _, err := quizzes.UpdateQuestion(ctx, author, quiz.Questions[0].ID, request); !errors.Is(err, ErrValidation) {
+		t.Fatalf("changing the type of a question with options: got %v, want %v", err, ErrValidation)
+	}
+	single := models.QuestionTypeSingle
+	if _, err := quizzes.UpdateQuestion(ctx, author, quiz.Questions[0].ID, models.UpdateQuestionRequest{Type: &single}); err != nil {
+		t.Fatalf("keeping the type of a question with options: %v", err)
+	}
+
+	empty, err := quizzes.CreateQuestion(ctx, author, quiz.ID, models.CreateQuestionRequest{Question: "third?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	question, err := quizzes.UpdateQuestion(ctx, author, empty.ID, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if question.Type != ordering {
+		t.Fatalf("got type %q, want %q", question.Type, ordering)
+	}
+}
+
+func TestOnlyChoiceOptionsAreCorrect(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, _, quiz := takeable(t, nil)
+	author := &models.User{Base: models.Base{ID: quiz.AuthorID}, Role: models.RoleAuthor}
+
+	steps := []models.CreateOptionRequest{{Value: "first"}, {Value: "second"}}
+	ordering, err := quizzes.CreateQuestion(ctx, author, quiz.ID, models.CreateQuestionRequest{Question: "order?", Type: models.QuestionTypeOrdering, Options: &steps})
+	if err != nil {
+		t.Fatal(err)
+	}
+	correct := true
+	if _, err = quizzes.UpdateOption(ctx, author, ordering.Options[0].ID, models.UpdateOptionRequest{IsCorrect: &correct}); !errors.Is(err, ErrValidation) {
+		t.Fatalf("marking an ordering option correct: got %v, want %v", err, ErrValidation)
+	}
+	if _, err = quizzes.CreateOption(ctx, author, ordering.ID, models.CreateOptionRequest{Value: "third", IsCorrect: true}); !errors.Is(err, ErrValidation) {
+		t.Fatalf("adding a correct ordering option: got %v, want %v", err, ErrValidation)
+	}
+
+	option, err := quizzes.UpdateOption(ctx, author, wrongOption(t, &quiz.Questions[0]), models.UpdateOptionRequest{IsCorrect: &correct})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !option.IsCorrect {
+		t.Fatal("the option of a single question was not marked correct")
+	}
+}
+
+func TestReorderQuestions(t *testing.T) {
+	ctx := context.Background()
+	quizzes, _, _, quiz := takeable(t, nil)
+	author := &models.User{Base: models.Base{ID: quiz.AuthorID}, Role: models.RoleAuthor}
+	first, second := quiz.Questions[0].ID, quiz.Questions[1].ID
+
+	if _, err := quizzes.ReorderQuestions(ctx, author, quiz.ID, models.ReorderRequest{IDs: []uint32{second}}); !errors.Is(err, ErrValidation) {
+		t.Fatalf("reordering only some questions: got %v, want %v", err, ErrValidation)
+	}
+	if _, err := quizzes.ReorderQuestions(ctx, author, quiz.ID, models.ReorderRequest{IDs: []uint32{second, second}}); !errors.Is(err, ErrValidation) {
+		t.Fatalf("reordering with a repeated question: got %v, want %v", err, ErrValidation)
+	}
+	reordered, err := quizzes.ReorderQuestions(ctx, author, quiz.ID, models.ReorderRequest{IDs: []uint32{second, first}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reordered.Questions[0].ID != second || reordered.Questions[1].ID != first {
+		t.Fatalf("got questions %d, %d, want %d, %d", reordered.Questions[0].ID, reordered.Questions[1].ID, second, first)
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+// gradeQuestions returns the credit and points every question of the quiz earned with the given answers.
+// Questions without an answer earn no credit and lose the unanswered penalty of the quiz,
+// answered ones without any credit lose its wrong penalty.
+func gradeQuestions(quiz *models.Quiz, answers []models.Answer) []models.QuestionResult {
+	picked := make(map[uint32]models.Answer, len(answers))
+	given := make(map[uint32]models.Answer)
+	answered := make(map[uint32]bool)
+	for _, a := range answers {
+		answered[a.QuestionID] = true
+		if a.OptionID != 0 {
+			picked[a.OptionID] = a
+		} else {
+			given[a.QuestionID] = a
+		}
+	}
+
+	results := make([]models.QuestionResult, len(quiz.Questions))
+	for i := range quiz.Questions {
+		q := &quiz.Questions[i]
+		var credit float32
+		switch q.Type {
+		case models.QuestionTypeText:
+			if a, ok := given[q.ID]; ok && acceptsText(q, a.Text) {
+				credit = 1
+			}
+		case models.QuestionTypeNumeric:
+			if a, ok := given[q.ID]; ok && acceptsNumber(q, a.Number) {
+				credit = 1
+			}
+		case models.QuestionTypeOrdering:
+			credit = gradeOrder(q, picked)
+		case models.QuestionTypeMatching:
+			credit = gradePairs(q, picked)
+		default:
+			credit = gradeOptions(q, picked)
+		}
+		result := models.QuestionResult{QuestionID: q.ID, Credit: credit, Answered: answered[q.ID], MaxPoints: q.Points}
+		switch {
+		case !result.Answered:
+			result.Points = -quiz.UnansweredPenalty * q.Points
+		case credit == 0:
+			result.Points = -quiz.WrongPenalty * q.Points
+		default:
+			result.Points = credit * q.Points
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// gradeOptions returns the credit between 0 and 1 the picked options earn on a single or multiple question
+func gradeOptions(question *models.Question, picked map[uint32]models.Answer) float32 {
+	var correct, wrong, correctPicks, wrongPicks int
+	for _, o := range question.Options {
+		_, ok := picked[o.ID]
+		switch {
+		case o.IsCorrect:
+			correct++
+			if ok {
+				correctPicks++
+			}
+		default:
+			wrong++
+			if ok {
+				wrongPicks++
+			}
+		}
+	}
+	if correct == 0 {
+		return 0
+	}
+
+	switch {
+	case question.Type != models.QuestionTypeMultiple:
+		// a single question is answered right by any of its correct options
+		if correctPicks > 0 {
+			return 1
+		}
+		return 0
+	case question.Scoring == models.ScoringPartial:
+		credit := float32(correctPicks) / float32(correct)
+		if wrong > 0 {
+			credit -= question.Penalty * float32(wrongPicks) / float32(wrong)
+		}
+		return max(credit, 0)
+	default:
+		if correctPicks == correct && wrongPicks == 0 {
+			return 1
+		}
+		return 0
+	}
+}
+
+// gradeOrder returns the credit between 0 and 1 of an ordering question,
+// partial scoring credits every option put at its own place in the order
+func gradeOrder(question *models.Question, picked map[uint32]models.Answer) float32 {
+	steps := slices.Clone(question.Options)
+	slices.SortStableFunc(steps, func(a, b models.Option) int {
+		if a.Step != b.Step {
+			return a.Step - b.Step
+		}
+		return int(a.ID) - int(b.ID)
+	})
+	right := 0
+	for i, o := range steps {
+		if a, ok := picked[o.ID]; ok && a.Position == i {
+			right++
+		}
+	}
+	return creditOf(question, right, len(steps))
+}
+
+// gradePairs returns the credit between 0 and 1 of a matching question,
+// partial scoring credits every option paired with its own item
+func gradePairs(question *models.Question, picked map[uint32]models.Answer) float32 {
+	right := 0
+	for _, o := range question.Options {
+		if a, ok := picked[o.ID]; ok && strings.EqualFold(strings.TrimSpace(a.Text), o.Pair) {
+			right++
+		}
+	}
+	return creditOf(question, right, len(question.Options))
+}
+
+// creditOf returns the share of right parts under partial scoring, otherwise 1 only when every part is right
+func creditOf(question *models.Question, right int, total int) float32 {
+	switch {
+	case total == 0:
+		return 0
+	case question.Scoring == models.ScoringPartial:
+		return float32(right) / float32(total)
+	case right == total:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// acceptsText reports whether the text matches one of the accepted answers of a text question
+func acceptsText(question *models.Question, text string) bool {
+	if question.Matching == models.MatchingRegex {
+		flags := "(?i)"
+		if question.CaseSensitive {
+			flags = ""
+		}
+		text = strings.TrimSpace(text)
+		for _, a := range question.AcceptedAnswers {
+			// patterns are validated when they are saved, one that no longer compiles accepts nothing
+			re, err := regexp.Compile(flags + `^(?:` + a + `)$`)
+			if err == nil && re.MatchString(text) {
+				return true
+			}
+		}
+		return false
+	}
+
+	normalize := func(s string) string {
+		s = strings.Join(strings.Fields(s), " ")
+		if !question.CaseSensitive {
+			s = strings.ToLower(s)
+		}
+		return s
+	}
+	text = normalize(text)
+	return slices.ContainsFunc(question.AcceptedAnswers, func(a string) bool {
+		return normalize(a) == text
+	})
+}
+
+// acceptsNumber reports whether the number is within the tolerance of the answer of a numeric question
+func acceptsNumber(question *models.Question, number *float64) bool {
+	if number == nil || question.NumericAnswer == nil {
+		return false
+	}
+	return math.Abs(*number-*question.NumericAnswer) <= question.Tolerance
+}
+
+// calculateScore totals the points of every question into a score of the quiz,
+// which passes when its percentage reaches the pass threshold of the quiz
+func calculateScore(quiz *models.Quiz, results []models.QuestionResult) models.Score {
+	score := models.Score{QuizID: quiz.ID}
+	for _, r := range results {
+		score.RawPoints += r.Points
+		score.MaxPoints += r.MaxPoints
+	}
+	if score.MaxPoints > 0 {
+		score.Percentage = max(score.RawPoints, 0) * 100 / score.MaxPoints
+	}
+	score.Score = score.Percentage / 100
+	score.Passed = score.Percentage >= quiz.PassThreshold
+	return score
+}
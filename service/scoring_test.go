@@ -0,0 +1,229 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+// picks answers the options with the given ids
+func picks(ids ...uint32) map[uint32]models.Answer {
+	picked := make(map[uint32]models.Answer, len(ids))
+	for _, id := range ids {
+		picked[id] = models.Answer{OptionID: id}
+	}
+	return picked
+}
+
+func option(id uint32, correct bool) models.Option {
+	o := models.Option{IsCorrect: correct}
+	o.ID = id
+	return o
+}
+
+func TestGradeOptions(t *testing.T) {
+	// options 1 and 2 are correct, 3 and 4 are wrong
+	options := []models.Option{option(1, true), option(2, true), option(3, false), option(4, false)}
+	single := &models.Question{Type: models.QuestionTypeSingle, Options: options}
+	multiple := &models.Question{Type: models.QuestionTypeMultiple, Options: options}
+	partial := &models.Question{Type: models.QuestionTypeMultiple, Scoring: models.ScoringPartial, Penalty: 1, Options: options}
+
+	tests := []struct {
+		name     string
+		question *models.Question
+		picked   map[uint32]models.Answer
+		want     float32
+	}{
+		{"single right", single, picks(2), 1},
+		{"single wrong", single, picks(3), 0},
+		{"single unanswered", single, picks(), 0},
+		{"multiple every right one", multiple, picks(1, 2), 1},
+		{"multiple missing one", multiple, picks(1), 0},
+		{"multiple with a wrong one", multiple, picks(1, 2, 3), 0},
+		{"partial half", partial, picks(1), 0.5},
+		{"partial with a wrong one", partial, picks(1, 2, 3), 0.5},
+		{"partial never below zero", partial, picks(1, 3, 4), 0},
+		{"no correct option", &models.Question{Options: []models.Option{option(5, false)}}, picks(5), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gradeOptions(tt.question, tt.picked); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGradeOrder(t *testing.T) {
+	// the correct order is 3, 1, 2
+	options := []models.Option{{Step: 1}, {Step: 2}, {Step: 0}}
+	for i := range options {
+		options[i].ID = uint32(i + 1)
+	}
+	order := func(ids ...uint32) map[uint32]models.Answer {
+		picked := make(map[uint32]models.Answer, len(ids))
+		for i, id := range ids {
+			picked[id] = models.Answer{OptionID: id, Position: i}
+		}
+		return picked
+	}
+	allOrNothing := &models.Question{Type: models.QuestionTypeOrdering, Options: options}
+	partial := &models.Question{Type: models.QuestionTypeOrdering, Scoring: models.ScoringPartial, Options: options}
+
+	tests := []struct {
+		name     string
+		question *models.Question
+		picked   map[uint32]models.Answer
+		want     float32
+	}{
+		{"right order", allOrNothing, order(3, 1, 2), 1},
+		{"wrong order", allOrNothing, order(1, 3, 2), 0},
+		{"unanswered", allOrNothing, order(), 0},
+		{"partial right order", partial, order(3, 1, 2), 1},
+		{"partial one in place", partial, order(1, 3, 2), float32(1) / 3},
+		{"partial none in place", partial, order(2, 3, 1), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gradeOrder(tt.question, tt.picked); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// options on the same step are ordered by id
+	tied := &models.Question{Type: models.QuestionTypeOrdering, Options: []models.Option{options[1], options[0]}}
+	tied.Options[0].Step, tied.Options[1].Step = 0, 0
+	if got := gradeOrder(tied, order(1, 2)); got != 1 {
+		t.Errorf("tied steps in id order: got %v, want 1", got)
+	}
+}
+
+func TestGradePairs(t *testing.T) {
+	options := []models.Option{{Pair: "Paris"}, {Pair: "Rome"}}
+	for i := range options {
+		options[i].ID = uint32(i + 1)
+	}
+	pairs := func(items ...string) map[uint32]models.Answer {
+		picked := make(map[uint32]models.Answer, len(items))
+		for i, item := range items {
+			picked[uint32(i+1)] = models.Answer{OptionID: uint32(i + 1), Text: item}
+		}
+		return picked
+	}
+	allOrNothing := &models.Question{Type: models.QuestionTypeMatching, Options: options}
+	partial := &models.Question{Type: models.QuestionTypeMatching, Scoring: models.ScoringPartial, Options: options}
+
+	tests := []struct {
+		name     string
+		question *models.Question
+		picked   map[uint32]models.Answer
+		want     float32
+	}{
+		{"every pair", allOrNothing, pairs("Paris", "Rome"), 1},
+		{"case and spaces are ignored", allOrNothing, pairs(" paris ", "ROME"), 1},
+		{"swapped", allOrNothing, pairs("Rome", "Paris"), 0},
+		{"one missing", allOrNothing, pairs("Paris"), 0},
+		{"partial one missing", partial, pairs("Paris"), 0.5},
+		{"partial swapped", partial, pairs("Rome", "Paris"), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gradePairs(tt.question, tt.picked); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAcceptsText(t *testing.T) {
+	exact := &models.Question{AcceptedAnswers: []string{"New York", "NYC"}, Matching: models.MatchingExact}
+	sensitive := &models.Question{AcceptedAnswers: []string{"New York"}, Matching: models.MatchingExact, CaseSensitive: true}
+	regex := &models.Question{AcceptedAnswers: []string{`colou?r`, `(`}, Matching: models.MatchingRegex}
+	sensitiveRegex := &models.Question{AcceptedAnswers: []string{`Colou?r`}, Matching: models.MatchingRegex, CaseSensitive: true}
+
+	tests := []struct {
+		name     string
+		question *models.Question
+		text     string
+		want     bool
+	}{
+		{"exact", exact, "New York", true},
+		{"another accepted answer", exact, "NYC", true},
+		{"case and spacing are ignored", exact, "  new   york ", true},
+		{"not accepted", exact, "Boston", false},
+		{"case sensitive", sensitive, "new york", false},
+		{"case sensitive exact", sensitive, " New  York", true},
+		{"regex", regex, "Color", true},
+		{"regex optional part", regex, " colour ", true},
+		{"regex matches the whole answer", regex, "colors", false},
+		{"regex case sensitive", sensitiveRegex, "colour", false},
+		{"regex case sensitive match", sensitiveRegex, "Colour", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := acceptsText(tt.question, tt.text); got != tt.want {
+				t.Errorf("acceptsText(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAcceptsNumber(t *testing.T) {
+	answer := 3.14
+	question := &models.Question{NumericAnswer: &answer, Tolerance: 0.01}
+	number := func(n float64) *float64 { return &n }
+
+	tests := []struct {
+		name     string
+		question *models.Question
+		number   *float64
+		want     bool
+	}{
+		{"exact", question, number(3.14), true},
+		{"within the tolerance", question, number(3.145), true},
+		{"outside the tolerance", question, number(3.2), false},
+		{"unanswered", question, nil, false},
+		{"no answer key", &models.Question{}, number(3.14), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := acceptsNumber(tt.question, tt.number); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateScore(t *testing.T) {
+	quiz := &models.Quiz{PassThreshold: 50}
+	quiz.ID = 7
+
+	tests := []struct {
+		name       string
+		results    []models.QuestionResult
+		raw        float32
+		percentage float32
+		passed     bool
+	}{
+		{"every point", []models.QuestionResult{{Points: 2, MaxPoints: 2}, {Points: 1, MaxPoints: 1}}, 3, 100, true},
+		{"pass threshold reached", []models.QuestionResult{{Points: 1, MaxPoints: 1}, {Points: 0, MaxPoints: 1}}, 1, 50, true},
+		{"below the pass threshold", []models.QuestionResult{{Points: 1, MaxPoints: 4}}, 1, 25, false},
+		{"penalties never make it negative", []models.QuestionResult{{Points: -1, MaxPoints: 1}, {Points: 0, MaxPoints: 1}}, -1, 0, false},
+		{"nothing to earn", nil, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := calculateScore(quiz, tt.results)
+			if score.QuizID != quiz.ID || score.RawPoints != tt.raw || score.Percentage != tt.percentage ||
+				score.Score != tt.percentage/100 || score.Passed != tt.passed {
+				t.Errorf("got %+v, want %v raw points, %v%% and passed %v", score, tt.raw, tt.percentage, tt.passed)
+			}
+		})
+	}
+
+	// without a pass threshold every score passes
+	if score := calculateScore(&models.Quiz{}, []models.QuestionResult{{Points: 0, MaxPoints: 1}}); !score.Passed {
+		t.Error("a score failed a quiz without a pass threshold")
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+// arrange fills the question and option orders of a new progression from its seed.
+// Ids are shuffled starting from ascending order so the same seed always gives the same orders.
+func arrange(progression *models.Progression, quiz *models.Quiz) {
+	progression.QuestionOrder = make([]uint32, len(quiz.Questions))
+	for i, q := range quiz.Questions {
+		progression.QuestionOrder[i] = q.ID
+	}
+	if quiz.ShuffleQuestions {
+		shuffle(progression.QuestionOrder, progression.Seed, 0)
+	}
+
+	if !quiz.ShuffleOptions {
+		return
+	}
+	progression.OptionOrder = make(map[uint32][]uint32, len(quiz.Questions))
+	for _, q := range quiz.Questions {
+		ids := make([]uint32, len(q.Options))
+		for i, o := range q.Options {
+			ids[i] = o.ID
+		}
+		// every question gets its own stream so adding one question's options does not move another's
+		shuffle(ids, progression.Seed, q.ID)
+		progression.OptionOrder[q.ID] = ids
+	}
+}
+
+func shuffle(ids []uint32, seed int64, stream uint32) {
+	slices.Sort(ids)
+	r := rand.New(rand.NewPCG(uint64(seed), uint64(stream)))
+	r.Shuffle(len(ids), func(i, j int) {
+		ids[i], ids[j] = ids[j], ids[i]
+	})
+}
+
+// questionOrder returns the question ids in the order the progression gets them.
+// Progressions begun before orders were stored follow the positions of the quiz.
+func questionOrder(progression *models.Progression, quiz *models.Quiz) []uint32 {
+	if len(progression.QuestionOrder) > 0 {
+		return progression.QuestionOrder
+	}
+	ids := make([]uint32, len(quiz.Questions))
+	for i, q := range quiz.Questions {
+		ids[i] = q.ID
+	}
+	return ids
+}
+
+// arrangeQuiz puts the questions and options of the quiz in the order the progression sees them
+func arrangeQuiz(progression *models.Progression, quiz *models.Quiz) {
+	sortByOrder(quiz.Questions, questionOrder(progression, quiz), func(q models.Question) uint32 { return q.ID })
+	for i := range quiz.Questions {
+		arrangeOptions(progression, &quiz.Questions[i])
+	}
+}
+
+// arrangeOptions puts the options of the question in the order the progression sees them
+func arrangeOptions(progression *models.Progression, question *models.Question) {
+	if order, ok := progression.OptionOrder[question.ID]; ok {
+		sortByOrder(question.Options, order, func(o models.Option) uint32 { return o.ID })
+	}
+}
+
+// sortByOrder sorts items by the index of their id in order, items missing from order keep their place after the others
+func sortByOrder[T any](items []T, order []uint32, id func(T) uint32) {
+	index := make(map[uint32]int, len(order))
+	for i, o := range order {
+		index[o] = i
+	}
+	rank := func(item T) int {
+		if i, ok := index[id(item)]; ok {
+			return i
+		}
+		return len(order)
+	}
+	slices.SortStableFunc(items, func(a, b T) int {
+		return rank(a) - rank(b)
+	})
+}
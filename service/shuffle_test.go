@@ -0,0 +1,78 @@
+package service
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+// shuffled returns a quiz of five questions with four options each that shuffles both
+func shuffled() *models.Quiz {
+	quiz := &models.Quiz{ShuffleQuestions: true, ShuffleOptions: true}
+	id := uint32(0)
+	for range 5 {
+		id++
+		question := models.Question{}
+		question.ID = id
+		for range 4 {
+			id++
+			o := models.Option{}
+			o.ID = id
+			question.Options = append(question.Options, o)
+		}
+		quiz.Questions = append(quiz.Questions, question)
+	}
+	return quiz
+}
+
+func TestArrangeIsReproducible(t *testing.T) {
+	quiz := shuffled()
+	first := &models.Progression{Seed: 42}
+	arrange(first, quiz)
+
+	// the order does not depend on the order the questions and options are read in
+	reversed := shuffled()
+	slices.Reverse(reversed.Questions)
+	for i := range reversed.Questions {
+		slices.Reverse(reversed.Questions[i].Options)
+	}
+	again := &models.Progression{Seed: 42}
+	arrange(again, reversed)
+
+	if !slices.Equal(first.QuestionOrder, again.QuestionOrder) {
+		t.Errorf("question orders differ for the same seed: %v and %v", first.QuestionOrder, again.QuestionOrder)
+	}
+	if !maps.EqualFunc(first.OptionOrder, again.OptionOrder, slices.Equal) {
+		t.Errorf("option orders differ for the same seed: %v and %v", first.OptionOrder, again.OptionOrder)
+	}
+
+	// some other seed orders the questions differently
+	differs := false
+	for seed := range int64(20) {
+		other := &models.Progression{Seed: seed + 100}
+		arrange(other, quiz)
+		if !slices.Equal(first.QuestionOrder, other.QuestionOrder) {
+			differs = true
+			break
+		}
+	}
+	if !differs {
+		t.Error("every seed gives the same question order")
+	}
+}
+
+func TestArrangeWithoutShuffling(t *testing.T) {
+	quiz := shuffled()
+	quiz.ShuffleQuestions, quiz.ShuffleOptions = false, false
+	progression := &models.Progression{Seed: 42}
+	arrange(progression, quiz)
+
+	if want := []uint32{1, 6, 11, 16, 21}; !slices.Equal(progression.QuestionOrder, want) {
+		t.Errorf("got question order %v, want %v", progression.QuestionOrder, want)
+	}
+	if progression.OptionOrder != nil {
+		t.Errorf("options were ordered without shuffling: %v", progression.OptionOrder)
+	}
+}
@@ -0,0 +1,494 @@
+package service
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/gob"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/store"
+)
+
+// memoryStore is an in-memory store.Store for tests. Records are copied in and out like rows of a database,
+// nested questions and options are kept apart from their quiz and put back together when the quiz is read.
+type memoryStore struct {
+	data *memoryData
+}
+
+// memoryData holds the records of a memoryStore, its fields are exported so transactions can copy it with gob
+type memoryData struct {
+	NextID       uint32
+	Users        map[uint32]models.User
+	Quizzes      map[uint32]models.Quiz
+	Questions    map[uint32]models.Question
+	Options      map[uint32]models.Option
+	Progressions map[uint32]models.Progression
+	Scores       map[uint32]models.Score
+	Answers      map[uint32]models.Answer
+	Versions     map[uint32]models.QuizVersion
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{data: &memoryData{
+		Users:        make(map[uint32]models.User),
+		Quizzes:      make(map[uint32]models.Quiz),
+		Questions:    make(map[uint32]models.Question),
+		Options:      make(map[uint32]models.Option),
+		Progressions: make(map[uint32]models.Progression),
+		Scores:       make(map[uint32]models.Score),
+		Answers:      make(map[uint32]models.Answer),
+		Versions:     make(map[uint32]models.QuizVersion),
+	}}
+}
+
+func (m *memoryStore) Users() store.UserStore               { return memoryUsers{m} }
+func (m *memoryStore) Quizzes() store.QuizStore             { return memoryQuizzes{m} }
+func (m *memoryStore) Progressions() store.ProgressionStore { return memoryProgressions{m} }
+func (m *memoryStore) Scores() store.ScoreStore             { return memoryScores{m} }
+func (m *memoryStore) Answers() store.AnswerStore           { return memoryAnswers{m} }
+func (m *memoryStore) Versions() store.VersionStore         { return memoryVersions{m} }
+
+// Transaction restores every record as it was before fn when fn fails
+func (m *memoryStore) Transaction(ctx context.Context, fn func(tx store.Store) error) error {
+	before := clone(*m.data)
+	if err := fn(m); err != nil {
+		*m.data = before
+		return err
+	}
+	return nil
+}
+
+// create gives a new record its id and timestamps
+func (m *memoryStore) create(base *models.Base) {
+	m.data.NextID++
+	base.ID = m.data.NextID
+	// like gorm, timestamps that are already set are kept
+	now := time.Now()
+	if base.CreatedAt.IsZero() {
+		base.CreatedAt = now
+	}
+	if base.UpdatedAt.IsZero() {
+		base.UpdatedAt = now
+	}
+}
+
+// clone deep copies v the way reading it back from a database would
+func clone[T any](v T) T {
+	var buf bytes.Buffer
+	var c T
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		panic(err)
+	}
+	if err := gob.NewDecoder(&buf).Decode(&c); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// get returns a copy of the record with the given id
+func get[T any](records map[uint32]T, id uint32) (*T, error) {
+	r, ok := records[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	c := clone(r)
+	return &c, nil
+}
+
+// list returns copies of the records that match keep ordered by compare and then by id, a nil compare orders them by id only
+func list[T any](records map[uint32]T, keep func(T) bool, compare func(a, b T) int) []T {
+	var matches []T
+	for _, id := range slices.Sorted(maps.Keys(records)) {
+		if keep(records[id]) {
+			matches = append(matches, clone(records[id]))
+		}
+	}
+	if compare != nil {
+		slices.SortStableFunc(matches, compare)
+	}
+	return matches
+}
+
+// page cuts a page out of items the way offset and limit do in a query
+func page[T any](items []T, offset int, limit int) []T {
+	items = items[min(offset, len(items)):]
+	if limit > 0 {
+		items = items[:min(limit, len(items))]
+	}
+	return items
+}
+
+type memoryUsers struct{ m *memoryStore }
+
+func (s memoryUsers) List(ctx context.Context, filter store.UserFilter) ([]models.User, int64, error) {
+	users := list(s.m.data.Users, func(u models.User) bool {
+		return (len(filter.IDs) == 0 || slices.Contains(filter.IDs, u.ID)) &&
+			strings.Contains(u.Name, filter.Name) &&
+			(filter.Role == "" || u.Role == filter.Role)
+	}, nil)
+	total := int64(len(users))
+	users = slices.DeleteFunc(users, func(u models.User) bool { return u.ID <= filter.AfterID })
+	return page(users, filter.Offset, filter.Limit), total, nil
+}
+
+func (s memoryUsers) Get(ctx context.Context, id uint32) (*models.User, error) {
+	user, err := get(s.m.data.Users, id)
+	if err != nil {
+		return nil, err
+	}
+	user.Answers = list(s.m.data.Answers, func(a models.Answer) bool { return a.UserID == id }, nil)
+	return user, nil
+}
+
+func (s memoryUsers) Find(ctx context.Context, id uint32) (*models.User, error) {
+	return get(s.m.data.Users, id)
+}
+
+func (s memoryUsers) GetByName(ctx context.Context, name string) (*models.User, error) {
+	for _, u := range s.m.data.Users {
+		if u.Name == name {
+			return get(s.m.data.Users, u.ID)
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (s memoryUsers) Create(ctx context.Context, user *models.User) error {
+	if _, err := s.GetByName(ctx, user.Name); err == nil {
+		return store.ErrConflict
+	}
+	s.m.create(&user.Base)
+	s.m.data.Users[user.ID] = clone(*user)
+	return nil
+}
+
+func (s memoryUsers) Update(ctx context.Context, user *models.User) error {
+	if other, err := s.GetByName(ctx, user.Name); err == nil && other.ID != user.ID {
+		return store.ErrConflict
+	}
+	user.UpdatedAt = time.Now()
+	s.m.data.Users[user.ID] = clone(*user)
+	return nil
+}
+
+func (s memoryUsers) Delete(ctx context.Context, id uint32) error {
+	delete(s.m.data.Users, id)
+	return nil
+}
+
+type memoryQuizzes struct{ m *memoryStore }
+
+func (s memoryQuizzes) List(ctx context.Context, filter store.QuizFilter) ([]models.Quiz, int64, error) {
+	quizzes := list(s.m.data.Quizzes, func(q models.Quiz) bool {
+		return (len(filter.IDs) == 0 || slices.Contains(filter.IDs, q.ID)) &&
+			strings.Contains(q.Name, filter.Name) &&
+			(filter.Status == "" || q.Status == filter.Status)
+	}, func(a, b models.Quiz) int {
+		var c int
+		switch strings.TrimPrefix(filter.Sort, "-") {
+		case "id":
+			c = cmp.Compare(a.ID, b.ID)
+		case "name":
+			c = cmp.Compare(a.Name, b.Name)
+		case "createdAt":
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		}
+		if strings.HasPrefix(filter.Sort, "-") {
+			return -c
+		}
+		return c
+	})
+	return page(quizzes, filter.Offset, filter.Limit), int64(len(quizzes)), nil
+}
+
+func (s memoryQuizzes) Get(ctx context.Context, id uint32) (*models.Quiz, error) {
+	quiz, err := get(s.m.data.Quizzes, id)
+	if err != nil {
+		return nil, err
+	}
+	quiz.Questions = list(s.m.data.Questions, func(q models.Question) bool { return q.QuizID == id }, func(a, b models.Question) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+	for i := range quiz.Questions {
+		quiz.Questions[i].Options = s.optionsOf(quiz.Questions[i].ID)
+	}
+	return quiz, nil
+}
+
+func (s memoryQuizzes) Create(ctx context.Context, quiz *models.Quiz) error {
+	s.m.create(&quiz.Base)
+	return s.Update(ctx, quiz)
+}
+
+// Update saves the quiz along with its nested questions and options like gorm does
+func (s memoryQuizzes) Update(ctx context.Context, quiz *models.Quiz) error {
+	quiz.UpdatedAt = time.Now()
+	for i := range quiz.Questions {
+		quiz.Questions[i].QuizID = quiz.ID
+		s.saveQuestion(&quiz.Questions[i])
+	}
+	stored := clone(*quiz)
+	stored.Questions, stored.Answers = nil, nil
+	s.m.data.Quizzes[quiz.ID] = stored
+	return nil
+}
+
+func (s memoryQuizzes) Delete(ctx context.Context, id uint32) error {
+	for _, q := range s.m.data.Questions {
+		if q.QuizID == id {
+			_ = s.DeleteQuestion(ctx, q.ID)
+		}
+	}
+	maps.DeleteFunc(s.m.data.Answers, func(_ uint32, a models.Answer) bool { return a.QuizID == id })
+	maps.DeleteFunc(s.m.data.Scores, func(_ uint32, sc models.Score) bool { return sc.QuizID == id })
+	maps.DeleteFunc(s.m.data.Progressions, func(_ uint32, p models.Progression) bool { return p.QuizID == id })
+	maps.DeleteFunc(s.m.data.Versions, func(_ uint32, v models.QuizVersion) bool { return v.QuizID == id })
+	delete(s.m.data.Quizzes, id)
+	return nil
+}
+
+func (s memoryQuizzes) GetQuestion(ctx context.Context, id uint32) (*models.Question, error) {
+	question, err := get(s.m.data.Questions, id)
+	if err != nil {
+		return nil, err
+	}
+	question.Options = s.optionsOf(id)
+	return question, nil
+}
+
+func (s memoryQuizzes) CreateQuestion(ctx context.Context, question *models.Question) error {
+	s.saveQuestion(question)
+	return nil
+}
+
+func (s memoryQuizzes) UpdateQuestion(ctx context.Context, question *models.Question) error {
+	stored := clone(*question)
+	stored.UpdatedAt, stored.Options = time.Now(), nil
+	s.m.data.Questions[question.ID] = stored
+	return nil
+}
+
+func (s memoryQuizzes) DeleteQuestion(ctx context.Context, id uint32) error {
+	for _, o := range s.m.data.Options {
+		if o.QuestionID == id {
+			delete(s.m.data.Options, o.ID)
+		}
+	}
+	delete(s.m.data.Questions, id)
+	return nil
+}
+
+func (s memoryQuizzes) SetQuestionPositions(ctx context.Context, ids []uint32) error {
+	for i, id := range ids {
+		if q, ok := s.m.data.Questions[id]; ok {
+			q.Position = i
+			s.m.data.Questions[id] = q
+		}
+	}
+	return nil
+}
+
+func (s memoryQuizzes) GetOption(ctx context.Context, id uint32) (*models.Option, error) {
+	return get(s.m.data.Options, id)
+}
+
+func (s memoryQuizzes) CreateOption(ctx context.Context, option *models.Option) error {
+	s.m.create(&option.Base)
+	s.m.data.Options[option.ID] = clone(*option)
+	return nil
+}
+
+func (s memoryQuizzes) UpdateOption(ctx context.Context, option *models.Option) error {
+	option.UpdatedAt = time.Now()
+	s.m.data.Options[option.ID] = clone(*option)
+	return nil
+}
+
+func (s memoryQuizzes) DeleteOption(ctx context.Context, id uint32) error {
+	delete(s.m.data.Options, id)
+	return nil
+}
+
+func (s memoryQuizzes) SetOptionPositions(ctx context.Context, ids []uint32) error {
+	for i, id := range ids {
+		if o, ok := s.m.data.Options[id]; ok {
+			o.Position = i
+			s.m.data.Options[id] = o
+		}
+	}
+	return nil
+}
+
+// saveQuestion inserts or updates the question together with its nested options
+func (s memoryQuizzes) saveQuestion(question *models.Question) {
+	if question.ID == 0 {
+		s.m.create(&question.Base)
+	}
+	for i := range question.Options {
+		o := &question.Options[i]
+		o.QuestionID = question.ID
+		if o.ID == 0 {
+			s.m.create(&o.Base)
+		}
+		s.m.data.Options[o.ID] = clone(*o)
+	}
+	stored := clone(*question)
+	stored.Options = nil
+	s.m.data.Questions[question.ID] = stored
+}
+
+func (s memoryQuizzes) optionsOf(questionID uint32) []models.Option {
+	return list(s.m.data.Options, func(o models.Option) bool { return o.QuestionID == questionID }, func(a, b models.Option) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+}
+
+type memoryProgressions struct{ m *memoryStore }
+
+func (s memoryProgressions) Get(ctx context.Context, id uint32) (*models.Progression, error) {
+	return get(s.m.data.Progressions, id)
+}
+
+func (s memoryProgressions) Create(ctx context.Context, progression *models.Progression) error {
+	for _, p := range s.m.data.Progressions {
+		if p.UserID == progression.UserID && p.QuizID == progression.QuizID && p.Attempt == progression.Attempt {
+			return store.ErrConflict
+		}
+	}
+	s.m.create(&progression.Base)
+	s.m.data.Progressions[progression.ID] = clone(*progression)
+	return nil
+}
+
+func (s memoryProgressions) Update(ctx context.Context, progression *models.Progression) error {
+	progression.UpdatedAt = time.Now()
+	s.m.data.Progressions[progression.ID] = clone(*progression)
+	return nil
+}
+
+func (s memoryProgressions) Delete(ctx context.Context, id uint32) error {
+	delete(s.m.data.Progressions, id)
+	return nil
+}
+
+func (s memoryProgressions) ListStale(ctx context.Context, now time.Time, inactiveSince time.Time, limit int) ([]models.Progression, error) {
+	progressions := list(s.m.data.Progressions, func(p models.Progression) bool {
+		if p.Outcome != "" {
+			return false
+		}
+		if p.Deadline != nil {
+			return p.Deadline.Before(now)
+		}
+		return !inactiveSince.IsZero() && p.UpdatedAt.Before(inactiveSince)
+	}, nil)
+	return page(progressions, 0, limit), nil
+}
+
+func (s memoryProgressions) LatestForUserAndQuiz(ctx context.Context, userID uint32, quizID uint32) (*models.Progression, error) {
+	progressions := list(s.m.data.Progressions, func(p models.Progression) bool {
+		return p.UserID == userID && p.QuizID == quizID
+	}, func(a, b models.Progression) int { return cmp.Compare(a.Attempt, b.Attempt) })
+	if len(progressions) == 0 {
+		return nil, store.ErrNotFound
+	}
+	return &progressions[len(progressions)-1], nil
+}
+
+type memoryScores struct{ m *memoryStore }
+
+func (s memoryScores) Create(ctx context.Context, score *models.Score) error {
+	s.m.create(&score.Base)
+	s.m.data.Scores[score.ID] = clone(*score)
+	return nil
+}
+
+func (s memoryScores) GetForProgression(ctx context.Context, progressionID uint32) (*models.Score, error) {
+	scores := list(s.m.data.Scores, func(sc models.Score) bool { return sc.ProgressionID == progressionID }, nil)
+	if len(scores) == 0 {
+		return nil, store.ErrNotFound
+	}
+	return &scores[0], nil
+}
+
+func (s memoryScores) ListForUserAndQuiz(ctx context.Context, userID uint32, quizID uint32, versionID uint32) ([]models.Score, error) {
+	return list(s.m.data.Scores, func(sc models.Score) bool {
+		return sc.UserID == userID && sc.QuizID == quizID && (versionID == 0 || sc.VersionID == versionID)
+	}, byAttempt), nil
+}
+
+func (s memoryScores) ListForQuiz(ctx context.Context, quizID uint32, versionID uint32) ([]models.Score, error) {
+	return list(s.m.data.Scores, func(sc models.Score) bool {
+		return sc.QuizID == quizID && (versionID == 0 || sc.VersionID == versionID)
+	}, func(a, b models.Score) int {
+		return cmp.Or(cmp.Compare(a.UserID, b.UserID), byAttempt(a, b))
+	}), nil
+}
+
+type memoryAnswers struct{ m *memoryStore }
+
+func (s memoryAnswers) Create(ctx context.Context, answer *models.Answer) error {
+	s.m.create(&answer.Base)
+	s.m.data.Answers[answer.ID] = clone(*answer)
+	return nil
+}
+
+func (s memoryAnswers) ListForUserAndQuiz(ctx context.Context, userID uint32, quizID uint32) ([]models.Answer, error) {
+	return list(s.m.data.Answers, func(a models.Answer) bool { return a.UserID == userID && a.QuizID == quizID }, nil), nil
+}
+
+func (s memoryAnswers) ListForProgression(ctx context.Context, progressionID uint32) ([]models.Answer, error) {
+	return list(s.m.data.Answers, func(a models.Answer) bool { return a.ProgressionID == progressionID }, nil), nil
+}
+
+type memoryVersions struct{ m *memoryStore }
+
+func (s memoryVersions) List(ctx context.Context, quizID uint32) ([]models.QuizVersion, error) {
+	versions := list(s.m.data.Versions, func(v models.QuizVersion) bool { return v.QuizID == quizID }, byNumber)
+	for i := range versions {
+		versions[i].Snapshot = nil
+	}
+	return versions, nil
+}
+
+func (s memoryVersions) Get(ctx context.Context, id uint32) (*models.QuizVersion, error) {
+	return get(s.m.data.Versions, id)
+}
+
+func (s memoryVersions) GetByNumber(ctx context.Context, quizID uint32, number uint32) (*models.QuizVersion, error) {
+	for _, v := range s.m.data.Versions {
+		if v.QuizID == quizID && v.Number == number {
+			return get(s.m.data.Versions, v.ID)
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (s memoryVersions) Latest(ctx context.Context, quizID uint32) (*models.QuizVersion, error) {
+	versions := list(s.m.data.Versions, func(v models.QuizVersion) bool { return v.QuizID == quizID }, byNumber)
+	if len(versions) == 0 {
+		return nil, store.ErrNotFound
+	}
+	return &versions[len(versions)-1], nil
+}
+
+func (s memoryVersions) Create(ctx context.Context, version *models.QuizVersion) error {
+	if _, err := s.GetByNumber(ctx, version.QuizID, version.Number); err == nil {
+		return store.ErrConflict
+	}
+	s.m.create(&version.Base)
+	s.m.data.Versions[version.ID] = clone(*version)
+	return nil
+}
+
+func byAttempt(a, b models.Score) int {
+	return cmp.Compare(a.Attempt, b.Attempt)
+}
+
+func byNumber(a, b models.QuizVersion) int {
+	return cmp.Compare(a.Number, b.Number)
+}
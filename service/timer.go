@@ -0,0 +1,46 @@
+package service
+
+import (
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+// startTimers starts a new progression at now and sets its deadline when the quiz has a time limit
+func startTimers(progression *models.Progression, quiz *models.Quiz, now time.Time) {
+	progression.StartedAt = now
+	if quiz.TimeLimit > 0 {
+		deadline := now.Add(seconds(quiz.TimeLimit))
+		progression.Deadline = &deadline
+	}
+	startQuestion(progression, quiz, now)
+}
+
+// startQuestion starts the timer of the current question when it has a time limit,
+// which runs out with the progression at the latest
+func startQuestion(progression *models.Progression, quiz *models.Quiz, now time.Time) {
+	progression.QuestionDeadline = nil
+	question, ok := findQuestion(quiz, progression.CurrentQuestionID)
+	if progression.IsFinished || !ok || question.TimeLimit == 0 {
+		return
+	}
+	deadline := now.Add(seconds(question.TimeLimit))
+	if progression.Deadline != nil && progression.Deadline.Before(deadline) {
+		deadline = *progression.Deadline
+	}
+	progression.QuestionDeadline = &deadline
+}
+
+// timeIsUp reports whether the progression ran past its deadline
+func timeIsUp(progression *models.Progression, now time.Time) bool {
+	return progression.Deadline != nil && now.After(*progression.Deadline)
+}
+
+// questionTimeIsUp reports whether the current question of the progression ran past its deadline
+func questionTimeIsUp(progression *models.Progression, now time.Time) bool {
+	return progression.QuestionDeadline != nil && now.After(*progression.QuestionDeadline)
+}
+
+func seconds(n uint32) time.Duration {
+	return time.Duration(n) * time.Second
+}
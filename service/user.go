@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/store"
+	"github.com/lghtr35/quiz-maker/validation"
+)
+
+// UserService owns users and the scores, rankings and analyses of their quizzes
+type UserService struct {
+	store store.Store
+}
+
+func NewUserService(s store.Store) *UserService {
+	return &UserService{store: s}
+}
+
+// ListUsers returns a page of users matching the request, either by page number or by the cursor of a previous page
+func (s *UserService) ListUsers(ctx context.Context, request models.ReadUsersRequest) (*models.PaginationResponse, error) {
+	page, size := pageOf(request.PaginationRequest)
+	// one more user than asked is fetched to tell whether there is a next page
+	filter := store.UserFilter{
+		Limit: int(size) + 1,
+	}
+	if request.Cursor != nil && *request.Cursor != "" {
+		afterID, err := decodeCursor(*request.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.AfterID = afterID
+		page = 0
+	} else {
+		filter.Offset = int((page - 1) * size)
+	}
+	if request.IDList != nil {
+		filter.IDs = *request.IDList
+	}
+	if request.Name != nil {
+		filter.Name = *request.Name
+	}
+	if request.Role != nil {
+		filter.Role = *request.Role
+	}
+
+	users, total, err := s.store.Users().List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(users) > int(size)
+	if hasNext {
+		users = users[:size]
+	}
+	response := paginate(page, size, total, users)
+	if hasNext {
+		cursor := encodeCursor(users[len(users)-1].ID)
+		response.NextCursor = &cursor
+	}
+	return response, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, caller *models.User, id uint32) (*models.User, error) {
+	if err := canManageUser(caller, id); err != nil {
+		return nil, err
+	}
+	user, err := s.store.Users().Get(ctx, id)
+	if err != nil {
+		return nil, translate(err, ErrUserNotFound)
+	}
+	return user, nil
+}
+
+// CreateUser registers a user with a hashed password, names are unique as they are used to log in.
+// Users always register as takers, admins are made by operators with CreateAdmin.
+func (s *UserService) CreateUser(ctx context.Context, request models.CreateUserRequest) (*models.User, error) {
+	hash, err := hashPassword("password", request.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := models.User{
+		Name:         request.Name,
+		PasswordHash: hash,
+		Role:         models.RoleTaker,
+	}
+	if err = s.store.Users().Create(ctx, &user); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			return nil, ErrUserNameTaken
+		}
+		return nil, translate(err, ErrUserNotFound)
+	}
+	return &user, nil
+}
+
+// CreateAdmin makes the user with the given name an admin with the given password, registering them when there is no such user.
+// It is how operators bootstrap the first admin and is not reachable over HTTP.
+// It reports whether the user was registered.
+func (s *UserService) CreateAdmin(ctx context.Context, request models.CreateUserRequest) (*models.User, bool, error) {
+	if errs := validation.Struct(request); errs != nil {
+		return nil, false, ErrValidation.WithDetails(errs)
+	}
+	hash, err := hashPassword("password", request.Password)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var user *models.User
+	var created bool
+	err = s.store.Transaction(ctx, func(tx store.Store) error {
+		var err error
+		user, err = tx.Users().GetByName(ctx, request.Name)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			created = true
+			user = &models.User{Name: request.Name, PasswordHash: hash, Role: models.RoleAdmin}
+			return tx.Users().Create(ctx, user)
+		case err != nil:
+			return err
+		}
+		user.PasswordHash = hash
+		user.Role = models.RoleAdmin
+		return tx.Users().Update(ctx, user)
+	})
+	if err != nil {
+		return nil, false, translate(err, ErrUserNotFound)
+	}
+	return user, created, nil
+}
+
+// UpdateUser changes the name or password of the caller, admins can change every user and their roles
+func (s *UserService) UpdateUser(ctx context.Context, caller *models.User, request models.UpdateUserRequest) (*models.User, error) {
+	if err := canManageUser(caller, request.ID); err != nil {
+		return nil, err
+	}
+	if request.Role != nil && !isAdmin(caller) {
+		return nil, ErrForbidden.WithDetails("only admins can change roles")
+	}
+
+	user, err := s.store.Users().Find(ctx, request.ID)
+	if err != nil {
+		return nil, translate(err, ErrUserNotFound)
+	}
+
+	if request.Name != nil && *request.Name != "" {
+		user.Name = *request.Name
+	}
+	if request.Password != nil {
+		if user.PasswordHash, err = hashPassword("password", *request.Password); err != nil {
+			return nil, err
+		}
+	}
+	if request.Role != nil {
+		user.Role = *request.Role
+	}
+
+	if err = s.store.Users().Update(ctx, user); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			return nil, ErrUserNameTaken
+		}
+		return nil, translate(err, ErrUserNotFound)
+	}
+	return user, nil
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, caller *models.User, id uint32) error {
+	if err := canManageUser(caller, id); err != nil {
+		return err
+	}
+	return translate(s.store.Users().Delete(ctx, id), ErrUserNotFound)
+}
+
+// GetScore returns the score of the user that counts under the score policy of the quiz, or the score of the attempt asked for
+func (s *UserService) GetScore(ctx context.Context, caller *models.User, userID uint32, quizID uint32, request models.ReadResultRequest) (*models.Score, error) {
+	if err := canSeeResults(ctx, s.store, caller, userID, quizID); err != nil {
+		return nil, err
+	}
+	quiz, scores, err := s.scoresOf(ctx, userID, quizID, request.Version)
+	if err != nil {
+		return nil, err
+	}
+	if request.Attempt != 0 {
+		score, err := scoreOfAttempt(scores, request.Attempt)
+		if err != nil {
+			return nil, err
+		}
+		return &score, nil
+	}
+	score := countedScore(quiz, scores)
+	return &score, nil
+}
+
+// GetRanking places the user's counted score among the counted scores of every user of the quiz,
+// or of one version of it when a version is given
+func (s *UserService) GetRanking(ctx context.Context, caller *models.User, userID uint32, quizID uint32, request models.ReadResultRequest) (*models.ReadUserRankingByScoreResponse, error) {
+	if err := canSeeResults(ctx, s.store, caller, userID, quizID); err != nil {
+		return nil, err
+	}
+	quiz, err := s.store.Quizzes().Get(ctx, quizID)
+	if err != nil {
+		return nil, translate(err, ErrQuizNotFound)
+	}
+	versionID, err := s.versionID(ctx, quizID, request.Version)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := s.store.Scores().ListForQuiz(ctx, quizID, versionID)
+	if err != nil {
+		return nil, err
+	}
+	scores = countedScores(quiz, scores)
+
+	totalOpponentCount := len(scores)
+	userPlace := 0
+	var userScore models.Score
+	for i, sc := range scores {
+		if sc.UserID == userID {
+			userPlace = i + 1
+			userScore = scores[i]
+			break
+		}
+	}
+	percent := (1 - (float32(userPlace) / float32(totalOpponentCount))) * 100
+	response := models.ReadUserRankingByScoreResponse{
+		Percent: percent,
+		Message: fmt.Sprintf("You were better than %.2f%% of all quizzers", percent),
+		Score:   userScore,
+		Rank:    uint32(userPlace),
+	}
+
+	if percent == 0 && userPlace == 1 {
+		response.Message = "You were the only person to finish this quiz yet."
+	}
+
+	if userPlace == 0 {
+		response.Message = "Score of this quiz has not been found."
+		response.Percent = 0
+		response.Score = models.Score{}
+		response.Rank = 0
+	}
+
+	return &response, nil
+}
+
+// GetScoreAnalysis compares the answers of the user with the answer key of the version of the quiz they took and grades every question.
+// The attempt asked for is analysed, otherwise the one the score policy counts or the latest one when it counts the average.
+// It also returns whether the caller may see the answer key, takers only get to see their own answers.
+func (s *UserService) GetScoreAnalysis(ctx context.Context, caller *models.User, userID uint32, quizID uint32, request models.ReadResultRequest) (*models.ReadUserScoreAnalysis, bool, error) {
+	if err := canSeeResults(ctx, s.store, caller, userID, quizID); err != nil {
+		return nil, false, err
+	}
+	user, err := s.store.Users().Find(ctx, userID)
+	if err != nil {
+		return nil, false, translate(err, ErrUserNotFound)
+	}
+	quiz, scores, err := s.scoresOf(ctx, userID, quizID, request.Version)
+	if err != nil {
+		return nil, false, err
+	}
+	full := canSeeAnswerKey(caller, quiz)
+	counted := countedScore(quiz, scores)
+	score := analysedScore(quiz, scores)
+	if request.Attempt != 0 {
+		if score, err = scoreOfAttempt(scores, request.Attempt); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// scores from before quizzes had versions are analysed on the quiz as it is now
+	var number uint32
+	if score.VersionID != 0 {
+		version, err := s.store.Versions().Get(ctx, score.VersionID)
+		if err != nil {
+			return nil, false, translate(err, ErrVersionNotFound)
+		}
+		quiz, number = version.Snapshot, version.Number
+	}
+
+	// scores from before progressions were kept only have the answers of the user on the quiz
+	var progression *models.Progression
+	if score.ProgressionID == 0 {
+		user.Answers, err = s.store.Answers().ListForUserAndQuiz(ctx, userID, quizID)
+	} else {
+		progression, err = s.store.Progressions().Get(ctx, score.ProgressionID)
+		if err != nil {
+			return nil, false, translate(err, ErrProgressionNotFound)
+		}
+		arrangeQuiz(progression, quiz)
+		user.Answers, err = s.store.Answers().ListForProgression(ctx, progression.ID)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// text and number answers are part of user.Answers and have no option
+	chosen := make(map[uint32]bool, len(user.Answers))
+	for _, a := range user.Answers {
+		chosen[a.OptionID] = true
+	}
+	userOptions := make([]models.Option, 0, len(user.Answers))
+	correctOptions := make([]models.Option, 0)
+	for _, q := range quiz.Questions {
+		for _, o := range q.Options {
+			if chosen[o.ID] {
+				userOptions = append(userOptions, o)
+			}
+			if o.IsCorrect {
+				correctOptions = append(correctOptions, o)
+			}
+		}
+	}
+
+	return &models.ReadUserScoreAnalysis{
+		User:           *user,
+		Quiz:           *quiz,
+		Version:        number,
+		Progression:    progression,
+		Score:          score,
+		CountedScore:   counted,
+		UserAnswers:    userOptions,
+		CorrectAnswers: correctOptions,
+		Results:        gradeQuestions(quiz, user.Answers),
+	}, full, nil
+}
+
+// scoresOf returns the quiz with every score of the user on it in the order of their attempts, or on one version of it when a number is given
+func (s *UserService) scoresOf(ctx context.Context, userID uint32, quizID uint32, number uint32) (*models.Quiz, []models.Score, error) {
+	quiz, err := s.store.Quizzes().Get(ctx, quizID)
+	if err != nil {
+		return nil, nil, translate(err, ErrQuizNotFound)
+	}
+	versionID, err := s.versionID(ctx, quizID, number)
+	if err != nil {
+		return nil, nil, err
+	}
+	scores, err := s.store.Scores().ListForUserAndQuiz(ctx, userID, quizID, versionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(scores) == 0 {
+		return nil, nil, ErrScoreNotFound
+	}
+	return quiz, scores, nil
+}
+
+// versionID returns the id of the version of the quiz with the given number, or 0 when no number is given
+func (s *UserService) versionID(ctx context.Context, quizID uint32, number uint32) (uint32, error) {
+	if number == 0 {
+		return 0, nil
+	}
+	version, err := s.store.Versions().GetByNumber(ctx, quizID, number)
+	if err != nil {
+		return 0, translate(err, ErrVersionNotFound)
+	}
+	return version.ID, nil
+}
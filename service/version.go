@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"github.com/lghtr35/quiz-maker/store"
+)
+
+// ListVersions returns every version of the quiz without their snapshots.
+// Like GetVersion it is open to anyone who can see the quiz, caller is nil for anonymous requests.
+func (s *QuizService) ListVersions(ctx context.Context, caller *models.User, quizID uint32) ([]models.QuizVersion, error) {
+	if _, err := s.versionAnswerKey(ctx, caller, quizID); err != nil {
+		return nil, err
+	}
+	return s.store.Versions().List(ctx, quizID)
+}
+
+// GetVersion returns a version of the quiz with its snapshot and whether the caller may see its answer key.
+// caller is nil for anonymous requests.
+func (s *QuizService) GetVersion(ctx context.Context, caller *models.User, quizID uint32, number uint32) (*models.QuizVersion, bool, error) {
+	full, err := s.versionAnswerKey(ctx, caller, quizID)
+	if err != nil {
+		return nil, false, err
+	}
+	version, err := s.store.Versions().GetByNumber(ctx, quizID, number)
+	if err != nil {
+		return nil, false, translate(err, ErrVersionNotFound)
+	}
+	return version, full, nil
+}
+
+// versionAnswerKey checks that the quiz whose versions the caller reads exists and tells whether the caller may see their answer key
+func (s *QuizService) versionAnswerKey(ctx context.Context, caller *models.User, quizID uint32) (bool, error) {
+	quiz, err := s.store.Quizzes().Get(ctx, quizID)
+	if err != nil {
+		return false, translate(err, ErrQuizNotFound)
+	}
+	return canSeeAnswerKey(caller, quiz), nil
+}
+
+// currentVersion returns the version that matches the quiz as it is now.
+// A new version is saved when the quiz has been edited since its latest version or has none yet.
+func currentVersion(ctx context.Context, s store.Store, quiz *models.Quiz) (*models.QuizVersion, error) {
+	snapshot := snapshotOf(quiz)
+	checksum, err := checksumOf(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.Versions().Latest(ctx, quiz.ID)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		latest = &models.QuizVersion{}
+	case err != nil:
+		return nil, err
+	case latest.Checksum == "":
+		// versions saved by the migration carry no checksum yet
+		if latest.Checksum, err = checksumOf(latest.Snapshot); err != nil {
+			return nil, err
+		}
+	}
+	if latest.Checksum == checksum {
+		return latest, nil
+	}
+
+	version := models.QuizVersion{
+		QuizID:   quiz.ID,
+		Number:   latest.Number + 1,
+		Checksum: checksum,
+		Snapshot: snapshot,
+	}
+	if err = s.Versions().Create(ctx, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// snapshotOf copies the quiz with its questions and options, leaving out everything recorded about taking it
+func snapshotOf(quiz *models.Quiz) *models.Quiz {
+	snapshot := *quiz
+	snapshot.Answers = nil
+	snapshot.Questions = make([]models.Question, len(quiz.Questions))
+	for i, q := range quiz.Questions {
+		q.Options = make([]models.Option, len(quiz.Questions[i].Options))
+		for j, o := range quiz.Questions[i].Options {
+			o.Answers = nil
+			q.Options[j] = o
+		}
+		snapshot.Questions[i] = q
+	}
+	return &snapshot
+}
+
+// checksumOf hashes the content of a snapshot. Update times, the status and the attempt settings are left out
+// so saving, archiving or publishing a quiz again without changing its content keeps its version.
+func checksumOf(snapshot *models.Quiz) (string, error) {
+	if snapshot == nil {
+		return "", nil
+	}
+	content := snapshotOf(snapshot)
+	content.UpdatedAt = content.CreatedAt
+	content.Status = ""
+	content.MaxAttempts, content.Cooldown, content.ScorePolicy = 0, 0, ""
+	for i := range content.Questions {
+		q := &content.Questions[i]
+		q.UpdatedAt = q.CreatedAt
+		for j := range q.Options {
+			q.Options[j].UpdatedAt = q.Options[j].CreatedAt
+		}
+	}
+	b, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// publishedQuiz returns the quiz with the content of its latest version, which attempts begun now take.
+// Its status and attempt policy are the live ones as they are not part of versions.
+// Quizzes that were never published, or only before publishing saved versions, are returned as they are.
+func publishedQuiz(ctx context.Context, s store.Store, quiz *models.Quiz) (*models.Quiz, error) {
+	if quiz.Status == models.QuizStatusDraft {
+		return quiz, nil
+	}
+	version, err := s.Versions().Latest(ctx, quiz.ID)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return quiz, nil
+	case err != nil:
+		return nil, err
+	}
+	published := version.Snapshot
+	published.Status = quiz.Status
+	published.MaxAttempts, published.Cooldown, published.ScorePolicy = quiz.MaxAttempts, quiz.Cooldown, quiz.ScorePolicy
+	return published, nil
+}
+
+// quizOf returns the quiz the progression takes, which is the snapshot of its version.
+// Progressions begun before quizzes had versions take the quiz as it is now.
+func quizOf(ctx context.Context, s store.Store, progression *models.Progression) (*models.Quiz, error) {
+	return quizOfVersion(ctx, s, progression.QuizID, progression.VersionID)
+}
+
+func quizOfVersion(ctx context.Context, s store.Store, quizID uint32, versionID uint32) (*models.Quiz, error) {
+	if versionID == 0 {
+		quiz, err := s.Quizzes().Get(ctx, quizID)
+		if err != nil {
+			return nil, translate(err, ErrQuizNotFound)
+		}
+		return quiz, nil
+	}
+	version, err := s.Versions().Get(ctx, versionID)
+	if err != nil {
+		return nil, translate(err, ErrVersionNotFound)
+	}
+	return version.Snapshot, nil
+}
+
+// findQuestion returns the question of the quiz with the given id
+func findQuestion(quiz *models.Quiz, id uint32) (*models.Question, bool) {
+	for i := range quiz.Questions {
+		if quiz.Questions[i].ID == id {
+			return &quiz.Questions[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,439 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormStore implements Store on top of a gorm connection
+type GormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+func (s *GormStore) Users() UserStore {
+	return &gormUserStore{db: s.db}
+}
+
+func (s *GormStore) Quizzes() QuizStore {
+	return &gormQuizStore{db: s.db}
+}
+
+func (s *GormStore) Progressions() ProgressionStore {
+	return &gormProgressionStore{db: s.db}
+}
+
+func (s *GormStore) Scores() ScoreStore {
+	return &gormScoreStore{db: s.db}
+}
+
+func (s *GormStore) Answers() AnswerStore {
+	return &gormAnswerStore{db: s.db}
+}
+
+func (s *GormStore) Versions() VersionStore {
+	return &gormVersionStore{db: s.db}
+}
+
+func (s *GormStore) Transaction(ctx context.Context, fn func(tx Store) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewGormStore(tx))
+	})
+}
+
+// Ping checks that the database can still be reached
+func (s *GormStore) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// translate converts gorm errors into store errors
+func translate(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrConflict
+	default:
+		return err
+	}
+}
+
+type gormUserStore struct {
+	db *gorm.DB
+}
+
+func (s *gormUserStore) List(ctx context.Context, filter UserFilter) ([]models.User, int64, error) {
+	q := s.db.WithContext(ctx).Model(&models.User{})
+	if len(filter.IDs) > 0 {
+		q = q.Where("id IN ?", filter.IDs)
+	}
+	if filter.Name != "" {
+		// obtain a search string like '%name%'
+		q = q.Where("name LIKE ?", fmt.Sprintf("%%%s%%", filter.Name))
+	}
+	if filter.Role != "" {
+		q = q.Where("role = ?", filter.Role)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, translate(err)
+	}
+
+	if filter.AfterID > 0 {
+		q = q.Where("id > ?", filter.AfterID)
+	}
+	var users []models.User
+	if err := q.Order("id").Offset(filter.Offset).Limit(filter.Limit).Find(&users).Error; err != nil {
+		return nil, 0, translate(err)
+	}
+	return users, total, nil
+}
+
+func (s *gormUserStore) Get(ctx context.Context, id uint32) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Preload(clause.Associations).First(&user, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &user, nil
+}
+
+func (s *gormUserStore) Find(ctx context.Context, id uint32) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &user, nil
+}
+
+func (s *gormUserStore) GetByName(ctx context.Context, name string) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("name = ?", name).First(&user).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &user, nil
+}
+
+func (s *gormUserStore) Create(ctx context.Context, user *models.User) error {
+	return translate(s.db.WithContext(ctx).Create(user).Error)
+}
+
+func (s *gormUserStore) Update(ctx context.Context, user *models.User) error {
+	return translate(s.db.WithContext(ctx).Save(user).Error)
+}
+
+func (s *gormUserStore) Delete(ctx context.Context, id uint32) error {
+	return translate(s.db.WithContext(ctx).Delete(&models.User{}, id).Error)
+}
+
+type gormQuizStore struct {
+	db *gorm.DB
+}
+
+// quizSortColumns maps models.QuizSortFields to their columns
+var quizSortColumns = map[string]string{
+	"id":        "id",
+	"name":      "name",
+	"createdAt": "created_at",
+}
+
+func (s *gormQuizStore) List(ctx context.Context, filter QuizFilter) ([]models.Quiz, int64, error) {
+	q := s.db.WithContext(ctx).Model(&models.Quiz{})
+	if len(filter.IDs) > 0 {
+		q = q.Where("id IN ?", filter.IDs)
+	}
+	if filter.Name != "" {
+		// obtain a search string like '%name%'
+		q = q.Where("name LIKE ?", fmt.Sprintf("%%%s%%", filter.Name))
+	}
+	if filter.Status != "" {
+		q = q.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, translate(err)
+	}
+
+	order := "id"
+	if column, ok := quizSortColumns[strings.TrimPrefix(filter.Sort, "-")]; ok {
+		order = column
+	}
+	var quizzes []models.Quiz
+	err := q.Order(clause.OrderByColumn{Column: clause.Column{Name: order}, Desc: strings.HasPrefix(filter.Sort, "-")}).
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&quizzes).Error
+	if err != nil {
+		return nil, 0, translate(err)
+	}
+	return quizzes, total, nil
+}
+
+func (s *gormQuizStore) Get(ctx context.Context, id uint32) (*models.Quiz, error) {
+	var quiz models.Quiz
+	err := s.db.WithContext(ctx).
+		Preload("Questions", byPosition).
+		Preload("Questions.Options", byPosition).
+		First(&quiz, id).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &quiz, nil
+}
+
+func (s *gormQuizStore) Create(ctx context.Context, quiz *models.Quiz) error {
+	return translate(s.db.WithContext(ctx).Create(quiz).Error)
+}
+
+func (s *gormQuizStore) Update(ctx context.Context, quiz *models.Quiz) error {
+	return translate(s.db.WithContext(ctx).Save(quiz).Error)
+}
+
+func (s *gormQuizStore) Delete(ctx context.Context, id uint32) error {
+	db := s.db.WithContext(ctx)
+	questions := db.Model(&models.Question{}).Select("id").Where("quiz_id = ?", id)
+	if err := db.Where("question_id IN (?)", questions).Delete(&models.Option{}).Error; err != nil {
+		return translate(err)
+	}
+	for _, model := range []any{&models.Answer{}, &models.Score{}, &models.Progression{}, &models.QuizVersion{}, &models.Question{}} {
+		if err := db.Where("quiz_id = ?", id).Delete(model).Error; err != nil {
+			return translate(err)
+		}
+	}
+	return translate(db.Delete(&models.Quiz{}, id).Error)
+}
+
+func (s *gormQuizStore) GetQuestion(ctx context.Context, id uint32) (*models.Question, error) {
+	var question models.Question
+	if err := s.db.WithContext(ctx).Preload("Options", byPosition).First(&question, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &question, nil
+}
+
+func (s *gormQuizStore) CreateQuestion(ctx context.Context, question *models.Question) error {
+	return translate(s.db.WithContext(ctx).Create(question).Error)
+}
+
+func (s *gormQuizStore) UpdateQuestion(ctx context.Context, question *models.Question) error {
+	return translate(s.db.WithContext(ctx).Omit(clause.Associations).Save(question).Error)
+}
+
+func (s *gormQuizStore) DeleteQuestion(ctx context.Context, id uint32) error {
+	return translate(s.db.WithContext(ctx).Select("Options").Delete(&models.Question{Base: models.Base{ID: id}}).Error)
+}
+
+func (s *gormQuizStore) SetQuestionPositions(ctx context.Context, ids []uint32) error {
+	return setPositions(s.db.WithContext(ctx), &models.Question{}, ids)
+}
+
+func (s *gormQuizStore) GetOption(ctx context.Context, id uint32) (*models.Option, error) {
+	var option models.Option
+	if err := s.db.WithContext(ctx).First(&option, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &option, nil
+}
+
+func (s *gormQuizStore) CreateOption(ctx context.Context, option *models.Option) error {
+	return translate(s.db.WithContext(ctx).Create(option).Error)
+}
+
+func (s *gormQuizStore) UpdateOption(ctx context.Context, option *models.Option) error {
+	return translate(s.db.WithContext(ctx).Save(option).Error)
+}
+
+func (s *gormQuizStore) DeleteOption(ctx context.Context, id uint32) error {
+	return translate(s.db.WithContext(ctx).Delete(&models.Option{}, id).Error)
+}
+
+func (s *gormQuizStore) SetOptionPositions(ctx context.Context, ids []uint32) error {
+	return setPositions(s.db.WithContext(ctx), &models.Option{}, ids)
+}
+
+// byPosition orders questions and options the way their author arranged them
+func byPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position").Order("id")
+}
+
+// setPositions numbers the rows of model with the given ids in the order of ids
+func setPositions(db *gorm.DB, model any, ids []uint32) error {
+	for i, id := range ids {
+		if err := db.Model(model).Where("id = ?", id).UpdateColumn("position", i).Error; err != nil {
+			return translate(err)
+		}
+	}
+	return nil
+}
+
+type gormProgressionStore struct {
+	db *gorm.DB
+}
+
+func (s *gormProgressionStore) Get(ctx context.Context, id uint32) (*models.Progression, error) {
+	var progression models.Progression
+	if err := s.db.WithContext(ctx).First(&progression, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &progression, nil
+}
+
+func (s *gormProgressionStore) Create(ctx context.Context, progression *models.Progression) error {
+	return translate(s.db.WithContext(ctx).Create(progression).Error)
+}
+
+func (s *gormProgressionStore) Update(ctx context.Context, progression *models.Progression) error {
+	return translate(s.db.WithContext(ctx).Save(progression).Error)
+}
+
+func (s *gormProgressionStore) Delete(ctx context.Context, id uint32) error {
+	return translate(s.db.WithContext(ctx).Delete(&models.Progression{}, id).Error)
+}
+
+func (s *gormProgressionStore) ListStale(ctx context.Context, now time.Time, inactiveSince time.Time, limit int) ([]models.Progression, error) {
+	db := s.db.WithContext(ctx).Where("outcome = ?", "")
+	if inactiveSince.IsZero() {
+		db = db.Where("deadline < ?", now)
+	} else {
+		// a progression with a running deadline is left to time out instead
+		db = db.Where("(deadline < ? OR (deadline IS NULL AND updated_at < ?))", now, inactiveSince)
+	}
+	var progressions []models.Progression
+	if err := db.Order("id").Limit(limit).Find(&progressions).Error; err != nil {
+		return nil, translate(err)
+	}
+	return progressions, nil
+}
+
+func (s *gormProgressionStore) LatestForUserAndQuiz(ctx context.Context, userID uint32, quizID uint32) (*models.Progression, error) {
+	var progression models.Progression
+	db := s.db.WithContext(ctx).Where("user_id = ? AND quiz_id = ?", userID, quizID)
+	if err := db.Order("attempt desc, id desc").First(&progression).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &progression, nil
+}
+
+type gormScoreStore struct {
+	db *gorm.DB
+}
+
+func (s *gormScoreStore) Create(ctx context.Context, score *models.Score) error {
+	return translate(s.db.WithContext(ctx).Create(score).Error)
+}
+
+func (s *gormScoreStore) GetForProgression(ctx context.Context, progressionID uint32) (*models.Score, error) {
+	var score models.Score
+	if err := s.db.WithContext(ctx).Where("progression_id = ?", progressionID).First(&score).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &score, nil
+}
+
+func (s *gormScoreStore) ListForUserAndQuiz(ctx context.Context, userID uint32, quizID uint32, versionID uint32) ([]models.Score, error) {
+	var scores []models.Score
+	db := s.db.WithContext(ctx).Where("user_id = ? AND quiz_id = ?", userID, quizID).Scopes(ofVersion(versionID))
+	if err := db.Order("attempt, id").Find(&scores).Error; err != nil {
+		return nil, translate(err)
+	}
+	return scores, nil
+}
+
+func (s *gormScoreStore) ListForQuiz(ctx context.Context, quizID uint32, versionID uint32) ([]models.Score, error) {
+	var scores []models.Score
+	db := s.db.WithContext(ctx).Where("quiz_id = ?", quizID).Scopes(ofVersion(versionID))
+	if err := db.Order("user_id, attempt, id").Find(&scores).Error; err != nil {
+		return nil, translate(err)
+	}
+	return scores, nil
+}
+
+// ofVersion keeps the rows of a single quiz version, or every row when versionID is 0
+func ofVersion(versionID uint32) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if versionID == 0 {
+			return db
+		}
+		return db.Where("version_id = ?", versionID)
+	}
+}
+
+type gormAnswerStore struct {
+	db *gorm.DB
+}
+
+func (s *gormAnswerStore) Create(ctx context.Context, answer *models.Answer) error {
+	return translate(s.db.WithContext(ctx).Create(answer).Error)
+}
+
+func (s *gormAnswerStore) ListForUserAndQuiz(ctx context.Context, userID uint32, quizID uint32) ([]models.Answer, error) {
+	var answers []models.Answer
+	if err := s.db.WithContext(ctx).Where("user_id = ? AND quiz_id = ?", userID, quizID).Find(&answers).Error; err != nil {
+		return nil, translate(err)
+	}
+	return answers, nil
+}
+
+func (s *gormAnswerStore) ListForProgression(ctx context.Context, progressionID uint32) ([]models.Answer, error) {
+	var answers []models.Answer
+	if err := s.db.WithContext(ctx).Where("progression_id = ?", progressionID).Find(&answers).Error; err != nil {
+		return nil, translate(err)
+	}
+	return answers, nil
+}
+
+type gormVersionStore struct {
+	db *gorm.DB
+}
+
+func (s *gormVersionStore) List(ctx context.Context, quizID uint32) ([]models.QuizVersion, error) {
+	versions := make([]models.QuizVersion, 0)
+	err := s.db.WithContext(ctx).Omit("snapshot").Where("quiz_id = ?", quizID).Order("number").Find(&versions).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return versions, nil
+}
+
+func (s *gormVersionStore) Get(ctx context.Context, id uint32) (*models.QuizVersion, error) {
+	var version models.QuizVersion
+	if err := s.db.WithContext(ctx).First(&version, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &version, nil
+}
+
+func (s *gormVersionStore) GetByNumber(ctx context.Context, quizID uint32, number uint32) (*models.QuizVersion, error) {
+	var version models.QuizVersion
+	if err := s.db.WithContext(ctx).Where("quiz_id = ? AND number = ?", quizID, number).First(&version).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &version, nil
+}
+
+func (s *gormVersionStore) Latest(ctx context.Context, quizID uint32) (*models.QuizVersion, error) {
+	var version models.QuizVersion
+	if err := s.db.WithContext(ctx).Where("quiz_id = ?", quizID).Order("number desc").First(&version).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &version, nil
+}
+
+func (s *gormVersionStore) Create(ctx context.Context, version *models.QuizVersion) error {
+	return translate(s.db.WithContext(ctx).Create(version).Error)
+}
@@ -0,0 +1,237 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/database"
+	"github.com/lghtr35/quiz-maker/migrations"
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+// openTestStore returns a GormStore on a migrated sqlite database that lives as long as the test
+func openTestStore(t *testing.T) *GormStore {
+	t.Helper()
+	db, err := database.Open(database.DriverSQLite, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if _, err = migrations.Up(db); err != nil {
+		t.Fatal(err)
+	}
+	return NewGormStore(db)
+}
+
+func TestDuplicateKeyIsConflict(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+
+	user := models.User{Name: "first"}
+	if err := s.Users().Create(ctx, &user); err != nil {
+		t.Fatal(err)
+	}
+	again := models.User{Base: models.Base{ID: user.ID}, Name: "second"}
+	if err := s.Users().Create(ctx, &again); !errors.Is(err, ErrConflict) {
+		t.Fatalf("creating a user with a taken id: got %v, want %v", err, ErrConflict)
+	}
+	namesake := models.User{Name: "first"}
+	if err := s.Users().Create(ctx, &namesake); !errors.Is(err, ErrConflict) {
+		t.Fatalf("creating a user with a taken name: got %v, want %v", err, ErrConflict)
+	}
+	attempt := models.Progression{UserID: user.ID, QuizID: 1, Attempt: 1}
+	if err := s.Progressions().Create(ctx, &attempt); err != nil {
+		t.Fatal(err)
+	}
+	attempt.ID = 0
+	if err := s.Progressions().Create(ctx, &attempt); !errors.Is(err, ErrConflict) {
+		t.Fatalf("beginning an attempt twice: got %v, want %v", err, ErrConflict)
+	}
+}
+
+func TestMissingRecordIsNotFound(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+
+	if _, err := s.Users().Get(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("getting a missing user: got %v, want %v", err, ErrNotFound)
+	}
+	if _, err := s.Users().Find(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("finding a missing user: got %v, want %v", err, ErrNotFound)
+	}
+	if _, err := s.Quizzes().Get(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("getting a missing quiz: got %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestListQuizzes(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	for _, name := range []string{"go basics", "sql", "go advanced"} {
+		if err := s.Quizzes().Create(ctx, &models.Quiz{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	quizzes, total, err := s.Quizzes().List(ctx, QuizFilter{Name: "go", Sort: "-name", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(quizzes) != 1 || quizzes[0].Name != "go basics" {
+		t.Fatalf("got %d of %d quizzes starting with %+v, want 1 of 2 starting with go basics", len(quizzes), total, quizzes)
+	}
+	if quizzes, _, err = s.Quizzes().List(ctx, QuizFilter{Name: "go", Sort: "-name", Offset: 1, Limit: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(quizzes) != 1 || quizzes[0].Name != "go advanced" {
+		t.Fatalf("got second page %+v, want go advanced", quizzes)
+	}
+}
+
+func TestSetPositions(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	quiz := models.Quiz{Name: "quiz"}
+	for i, text := range []string{"first?", "second?", "third?"} {
+		quiz.Questions = append(quiz.Questions, models.Question{Question: text, Position: i, Options: []models.Option{
+			{OptionBase: models.OptionBase{Value: "a", Position: 0}},
+			{OptionBase: models.OptionBase{Value: "b", Position: 1}},
+		}})
+	}
+	if err := s.Quizzes().Create(ctx, &quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	first, second, third := quiz.Questions[0].ID, quiz.Questions[1].ID, quiz.Questions[2].ID
+	if err := s.Quizzes().SetQuestionPositions(ctx, []uint32{third, first, second}); err != nil {
+		t.Fatal(err)
+	}
+	options := quiz.Questions[0].Options
+	if err := s.Quizzes().SetOptionPositions(ctx, []uint32{options[1].ID, options[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Quizzes().Get(ctx, quiz.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []uint32
+	for _, q := range got.Questions {
+		order = append(order, q.ID)
+	}
+	if !slices.Equal(order, []uint32{third, first, second}) {
+		t.Fatalf("got questions %v, want %v", order, []uint32{third, first, second})
+	}
+	if reordered := got.Questions[1].Options; reordered[0].Value != "b" || reordered[1].Value != "a" {
+		t.Fatalf("got options %q, %q, want b, a", reordered[0].Value, reordered[1].Value)
+	}
+	if untouched := got.Questions[0].Options; untouched[0].Value != "a" {
+		t.Fatalf("options of another question moved, got %q first", untouched[0].Value)
+	}
+}
+
+func TestListStale(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	now := time.Now()
+	past, future, old := now.Add(-time.Minute), now.Add(time.Hour), now.Add(-2*time.Hour)
+
+	progressions := map[string]*models.Progression{
+		"past deadline":            {Deadline: &past},
+		"inactive before deadline": {Deadline: &future, Base: models.Base{UpdatedAt: old}},
+		"inactive":                 {Base: models.Base{UpdatedAt: old}},
+		"active":                   {},
+		"closed":                   {Deadline: &past, Outcome: models.ProgressionOutcomeSubmitted},
+	}
+	// every progression is an attempt of its own
+	for i, name := range slices.Sorted(maps.Keys(progressions)) {
+		progressions[name].Attempt = uint32(i + 1)
+		if err := s.Progressions().Create(ctx, progressions[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stale := func(inactiveSince time.Time) []uint32 {
+		t.Helper()
+		got, err := s.Progressions().ListStale(ctx, now, inactiveSince, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []uint32
+		for _, p := range got {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+	ids := func(names ...string) []uint32 {
+		var ids []uint32
+		for _, name := range names {
+			ids = append(ids, progressions[name].ID)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	if got, want := stale(now.Add(-time.Hour)), ids("past deadline", "inactive"); !slices.Equal(got, want) {
+		t.Errorf("got stale progressions %v, want %v", got, want)
+	}
+	if got, want := stale(time.Time{}), ids("past deadline"); !slices.Equal(got, want) {
+		t.Errorf("without inactivity got stale progressions %v, want %v", got, want)
+	}
+}
+
+func TestDeleteQuiz(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	quizzes := []models.Quiz{{Name: "deleted"}, {Name: "kept"}}
+	for i := range quizzes {
+		quizzes[i].Questions = []models.Question{{Question: "?", Options: []models.Option{{OptionBase: models.OptionBase{Value: "a"}}}}}
+		if err := s.Quizzes().Create(ctx, &quizzes[i]); err != nil {
+			t.Fatal(err)
+		}
+		quiz := &quizzes[i]
+		version := models.QuizVersion{QuizID: quiz.ID, Number: 1}
+		if err := s.Versions().Create(ctx, &version); err != nil {
+			t.Fatal(err)
+		}
+		progression := models.Progression{UserID: 1, QuizID: quiz.ID, Attempt: 1, VersionID: version.ID}
+		if err := s.Progressions().Create(ctx, &progression); err != nil {
+			t.Fatal(err)
+		}
+		question := quiz.Questions[0]
+		answer := models.Answer{UserID: 1, QuizID: quiz.ID, QuestionID: question.ID, OptionID: question.Options[0].ID, ProgressionID: progression.ID}
+		if err := s.Answers().Create(ctx, &answer); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Scores().Create(ctx, &models.Score{UserID: 1, QuizID: quiz.ID, ProgressionID: progression.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, kept := quizzes[0], quizzes[1]
+	if err := s.Quizzes().Delete(ctx, deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, model := range []any{&models.Question{}, &models.Option{}, &models.Answer{}, &models.Score{}, &models.Progression{}, &models.QuizVersion{}} {
+		var count int64
+		if err := s.db.Model(model).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Errorf("%T: got %d rows, want only the one of the kept quiz", model, count)
+		}
+	}
+	if _, err := s.Quizzes().Get(ctx, kept.ID); err != nil {
+		t.Fatalf("the kept quiz is gone: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lghtr35/quiz-maker/models"
+)
+
+var (
+	// ErrNotFound is returned when the requested record does not exist
+	ErrNotFound = errors.New("store: record not found")
+	// ErrConflict is returned when a write violates a unique constraint
+	ErrConflict = errors.New("store: record conflicts with an existing one")
+)
+
+// Store gives access to every entity store backed by the same database
+type Store interface {
+	Users() UserStore
+	Quizzes() QuizStore
+	Progressions() ProgressionStore
+	Scores() ScoreStore
+	Answers() AnswerStore
+	Versions() VersionStore
+
+	// Transaction runs fn with a Store bound to a single database transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	Transaction(ctx context.Context, fn func(tx Store) error) error
+}
+
+// UserFilter narrows down the users returned by UserStore.List.
+// Users are ordered by id, AfterID skips every user up to and including that id.
+type UserFilter struct {
+	IDs     []uint32
+	Name    string
+	Role    string
+	AfterID uint32
+	Offset  int
+	Limit   int
+}
+
+// QuizFilter narrows down and orders the quizzes returned by QuizStore.List.
+// Sort is one of models.QuizSortFields optionally prefixed with - for descending order.
+type QuizFilter struct {
+	IDs    []uint32
+	Name   string
+	Status string
+	Sort   string
+	Offset int
+	Limit  int
+}
+
+type UserStore interface {
+	// List returns a page of users without their answers along with the count of every matching user regardless of AfterID
+	List(ctx context.Context, filter UserFilter) ([]models.User, int64, error)
+	// Get returns the user with its answers
+	Get(ctx context.Context, id uint32) (*models.User, error)
+	// Find returns the user without its answers
+	Find(ctx context.Context, id uint32) (*models.User, error)
+	// GetByName returns the user with the given name without its answers
+	GetByName(ctx context.Context, name string) (*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, id uint32) error
+}
+
+type QuizStore interface {
+	// List returns a page of quizzes without their questions along with the count of every matching quiz
+	List(ctx context.Context, filter QuizFilter) ([]models.Quiz, int64, error)
+	// Get returns the quiz with its questions and their options, both ordered by position
+	Get(ctx context.Context, id uint32) (*models.Quiz, error)
+	// Create inserts the quiz together with its nested questions and options
+	Create(ctx context.Context, quiz *models.Quiz) error
+	Update(ctx context.Context, quiz *models.Quiz) error
+	// Delete removes the quiz along with its questions, options and versions and the progressions, answers and scores taken on it
+	Delete(ctx context.Context, id uint32) error
+
+	// GetQuestion returns the question with its options ordered by position
+	GetQuestion(ctx context.Context, id uint32) (*models.Question, error)
+	// CreateQuestion inserts the question together with its nested options
+	CreateQuestion(ctx context.Context, question *models.Question) error
+	UpdateQuestion(ctx context.Context, question *models.Question) error
+	// DeleteQuestion removes the question along with its options
+	DeleteQuestion(ctx context.Context, id uint32) error
+	// SetQuestionPositions moves the questions with the given ids to their index in ids
+	SetQuestionPositions(ctx context.Context, ids []uint32) error
+
+	GetOption(ctx context.Context, id uint32) (*models.Option, error)
+	CreateOption(ctx context.Context, option *models.Option) error
+	UpdateOption(ctx context.Context, option *models.Option) error
+	DeleteOption(ctx context.Context, id uint32) error
+	// SetOptionPositions moves the options with the given ids to their index in ids
+	SetOptionPositions(ctx context.Context, ids []uint32) error
+}
+
+type ProgressionStore interface {
+	Get(ctx context.Context, id uint32) (*models.Progression, error)
+	Create(ctx context.Context, progression *models.Progression) error
+	Update(ctx context.Context, progression *models.Progression) error
+	Delete(ctx context.Context, id uint32) error
+	// ListStale returns up to limit open progressions whose deadline passed before now or that have no deadline
+	// and were last updated before inactiveSince, a zero inactiveSince leaves inactivity out
+	ListStale(ctx context.Context, now time.Time, inactiveSince time.Time, limit int) ([]models.Progression, error)
+	// LatestForUserAndQuiz returns the last attempt the user began on the quiz
+	LatestForUserAndQuiz(ctx context.Context, userID uint32, quizID uint32) (*models.Progression, error)
+}
+
+// ScoreStore narrows scores down to a single version of their quiz when versionID is not 0
+type ScoreStore interface {
+	Create(ctx context.Context, score *models.Score) error
+	GetForProgression(ctx context.Context, progressionID uint32) (*models.Score, error)
+	// ListForUserAndQuiz returns every score of the user on the quiz in the order of their attempts
+	ListForUserAndQuiz(ctx context.Context, userID uint32, quizID uint32, versionID uint32) ([]models.Score, error)
+	// ListForQuiz returns every score of the quiz in the order of the attempts of each user
+	ListForQuiz(ctx context.Context, quizID uint32, versionID uint32) ([]models.Score, error)
+}
+
+type AnswerStore interface {
+	Create(ctx context.Context, answer *models.Answer) error
+	ListForUserAndQuiz(ctx context.Context, userID uint32, quizID uint32) ([]models.Answer, error)
+	ListForProgression(ctx context.Context, progressionID uint32) ([]models.Answer, error)
+}
+
+type VersionStore interface {
+	// List returns every version of the quiz from the oldest to the newest without their snapshots
+	List(ctx context.Context, quizID uint32) ([]models.QuizVersion, error)
+	Get(ctx context.Context, id uint32) (*models.QuizVersion, error)
+	GetByNumber(ctx context.Context, quizID uint32, number uint32) (*models.QuizVersion, error)
+	// Latest returns the newest version of the quiz
+	Latest(ctx context.Context, quizID uint32) (*models.QuizVersion, error)
+	Create(ctx context.Context, version *models.QuizVersion) error
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"io"
 	"log"
+
+	"github.com/lghtr35/quiz-maker/models"
 )
 
 func ReadBodyAndUnmarshal[T any](val T, body io.ReadCloser) (T, error) {
@@ -41,3 +43,28 @@ func ReadBodyAndPrintJSON[T any](body io.ReadCloser) error {
 	log.Print(string(b))
 	return nil
 }
+
+// ReadBodyAndPrintError decodes an error envelope from body and prints it.
+// Bodies that are not an error envelope are printed as they are.
+func ReadBodyAndPrintError(status int, body io.ReadCloser) error {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	var errResp models.ErrorResponse
+	if err = json.Unmarshal(b, &errResp); err != nil || errResp.Code == "" {
+		log.Printf("Status: %d, Error: %s", status, string(b))
+		return nil
+	}
+
+	log.Printf("Status: %d, Error: %s (%s)", status, errResp.Message, errResp.Code)
+	if errResp.Details != nil {
+		details, err := json.MarshalIndent(errResp.Details, "", "  ")
+		if err != nil {
+			return err
+		}
+		log.Printf("Details: %s", string(details))
+	}
+	return nil
+}
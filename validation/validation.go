@@ -0,0 +1,178 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError describes why a single field of a request is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Errors is the list of every invalid field of a request
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fmt.Sprintf("%s %s", fe.Field, fe.Message)
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// Validator is implemented by requests with rules that cannot be expressed with binding tags.
+// Field names in the returned errors are relative to the validated value.
+type Validator interface {
+	Validate() Errors
+}
+
+// Struct checks v against its `binding` tags and Validate methods, descending into nested structs and slices.
+// Supported rules are required, min=N and max=N. Lengths are checked for strings and slices, values for numbers.
+// It returns nil when v is valid.
+func Struct(v any) Errors {
+	var errs Errors
+	walk(reflect.ValueOf(v), "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func walk(v reflect.Value, path string, errs *Errors) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		walkStruct(v, path, errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	}
+}
+
+func walkStruct(v reflect.Value, path string, errs *Errors) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		if field.Anonymous {
+			walk(fv, path, errs)
+			continue
+		}
+
+		name := join(path, jsonName(field))
+		if tag, ok := field.Tag.Lookup("binding"); ok {
+			for _, rule := range strings.Split(tag, ",") {
+				if msg := check(fv, strings.TrimSpace(rule)); msg != "" {
+					*errs = append(*errs, FieldError{Field: name, Message: msg})
+					break
+				}
+			}
+		}
+		walk(fv, name, errs)
+	}
+
+	if validator, ok := v.Interface().(Validator); ok {
+		for _, fe := range validator.Validate() {
+			*errs = append(*errs, FieldError{Field: join(path, fe.Field), Message: fe.Message})
+		}
+	}
+}
+
+// check applies a single rule to v and returns the failure message, or an empty string if it passes
+func check(v reflect.Value, rule string) string {
+	name, arg, _ := strings.Cut(rule, "=")
+	if name == "required" {
+		if isEmpty(v) {
+			return "is required"
+		}
+		return ""
+	}
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	limit, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return ""
+	}
+	size, format := measure(v)
+	switch name {
+	case "min":
+		if size < limit {
+			return fmt.Sprintf(format, "at least", arg)
+		}
+	case "max":
+		if size > limit {
+			return fmt.Sprintf(format, "at most", arg)
+		}
+	}
+	return ""
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// measure returns the size of v that min and max are compared to along with the format of the failure message
+func measure(v reflect.Value) (float64, string) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), "must be %s %s characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), "must contain %s %s items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), "must be %s %s"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), "must be %s %s"
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), "must be %s %s"
+	default:
+		return 0, "must be %s %s"
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+func join(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	if strings.HasPrefix(name, "[") {
+		return path + name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"slices"
+	"testing"
+)
+
+type item struct {
+	Value string `json:"value" binding:"required,max=5"`
+}
+
+type request struct {
+	Name     string   `json:"name" binding:"required"`
+	Count    int      `json:"count" binding:"min=1,max=10"`
+	Limit    *uint32  `json:"limit" binding:"max=3"`
+	Tags     []string `json:"tags" binding:"max=2"`
+	Items    []item   `json:"items"`
+	Nested   *item    `json:"nested"`
+	Untagged string
+}
+
+// Validate rejects requests naming themselves invalid
+func (r request) Validate() Errors {
+	if r.Name == "invalid" {
+		return Errors{{Field: "name", Message: "must not be invalid"}}
+	}
+	return nil
+}
+
+func TestStruct(t *testing.T) {
+	four := uint32(4)
+	tests := []struct {
+		name    string
+		request request
+		want    []string
+	}{
+		{"valid", request{Name: "quiz", Count: 1}, nil},
+		{"required", request{Name: "  ", Count: 1}, []string{"name is required"}},
+		{"min and max", request{Name: "quiz", Count: 11}, []string{"count must be at most 10"}},
+		{"pointers are checked when set", request{Name: "quiz", Count: 1, Limit: &four}, []string{"limit must be at most 3"}},
+		{"slice length", request{Name: "quiz", Count: 1, Tags: []string{"a", "b", "c"}}, []string{"tags must contain at most 2 items"}},
+		{"slice items", request{Name: "quiz", Count: 1, Items: []item{{Value: "ok"}, {Value: "too long"}}}, []string{"items[1].value must be at most 5 characters long"}},
+		{"nested struct", request{Name: "quiz", Count: 1, Nested: &item{}}, []string{"nested.value is required"}},
+		{"validate method", request{Name: "invalid", Count: 1}, []string{"name must not be invalid"}},
+		{"the first failing rule of a field", request{Name: "quiz", Count: 0}, []string{"count must be at least 1"}},
+		{"every invalid field", request{Count: 0}, []string{"name is required", "count must be at least 1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Struct(tt.request)
+			var got []string
+			for _, fe := range errs {
+				got = append(got, fe.Field+" "+fe.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if tt.want == nil && errs != nil {
+				t.Errorf("got empty errors %#v instead of nil", errs)
+			}
+		})
+	}
+}
+
+func TestStructOfPointer(t *testing.T) {
+	if errs := Struct(&request{Count: 1}); len(errs) != 1 || errs[0].Field != "name" {
+		t.Errorf("got %v, want name to be required", errs)
+	}
+	if errs := Struct((*request)(nil)); errs != nil {
+		t.Errorf("got %v for a nil request, want none", errs)
+	}
+}